@@ -0,0 +1,88 @@
+package baseapp
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// AdminPath is the path under which the admin API of the job manager is served.
+const AdminPath = "/admin/"
+
+// AdminHandler returns the HTTP handler of the job manager's admin API, which serves:
+//
+//	GET  /admin/jobs              the statuses of all jobs
+//	GET  /admin/jobs/{job}        the status of a job
+//	POST /admin/jobs/{job}/pause  pauses a job
+//	POST /admin/jobs/{job}/resume resumes a job
+//	POST /admin/jobs/{job}/run    runs a job right away
+//
+// Only requests with the given token in their "Authorization: Bearer <token>" header are served.
+func (jm *JobManager) AdminHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /admin/jobs", func(w http.ResponseWriter, _ *http.Request) {
+		statuses, err := jm.Statuses()
+		jm.writeAdminResponse(w, statuses, err)
+	})
+	mux.HandleFunc("GET /admin/jobs/{job}", func(w http.ResponseWriter, r *http.Request) {
+		status, err := jm.Status(r.PathValue("job"))
+		jm.writeAdminResponse(w, status, err)
+	})
+	mux.HandleFunc("POST /admin/jobs/{job}/pause", jm.adminAction(jm.Pause))
+	mux.HandleFunc("POST /admin/jobs/{job}/resume", jm.adminAction(jm.Resume))
+	mux.HandleFunc("POST /admin/jobs/{job}/run", jm.adminAction(jm.RunNow))
+
+	return adminAuth(token, mux)
+}
+
+// adminAuth returns a handler that only serves the requests with the given bearer token.
+func adminAuth(token string, next http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if token == "" || subtle.ConstantTimeCompare(got, want) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminAction returns the handler of an admin action on a job, which responds with the job's
+// status after the action.
+func (jm *JobManager) adminAction(action func(jobKey string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobKey := r.PathValue("job")
+		if err := action(jobKey); err != nil {
+			jm.writeAdminResponse(w, nil, err)
+			return
+		}
+		status, err := jm.Status(jobKey)
+		jm.writeAdminResponse(w, status, err)
+	}
+}
+
+// writeAdminResponse writes the given value as JSON, or the error with its matching status code.
+func (jm *JobManager) writeAdminResponse(w http.ResponseWriter, v any, err error) {
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, ErrRunNowUnsupported):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, ErrJobsNotRunning):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(v); err != nil {
+		jm.ctxFactory.logger.Error("failed to write admin response", "err", err)
+	}
+}
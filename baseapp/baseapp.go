@@ -33,6 +33,7 @@ func New(
 	logger log.Logger,
 	ethClient eth.Client,
 	jobs []job.Basic,
+	jobMgrCfg JobManagerConfig,
 	db ethdb.KeyValueStore,
 	svr *server.Server,
 	metrics telemetry.Metrics,
@@ -42,6 +43,7 @@ func New(
 		logger: logger,
 		jobMgr: NewManager(
 			jobs,
+			jobMgrCfg,
 			&contextFactory{
 				connPool: ethClient,
 				logger:   logger,
@@ -58,14 +60,22 @@ func (b *BaseApp) Logger() log.Logger {
 	return b.logger.With("namespace", "baseapp")
 }
 
+// JobManager returns the job manager of the baseapp, e.g. to pause, resume or run jobs.
+func (b *BaseApp) JobManager() *JobManager {
+	return b.jobMgr
+}
+
 // Start starts the baseapp.
 func (b *BaseApp) Start(ctx context.Context) error {
 	b.Logger().Info("attempting to start")
-	defer b.Logger().Info("successfully started")
 
 	// Start the job manager and the producers.
-	b.jobMgr.Start(ctx)
-	b.jobMgr.RunProducers(ctx)
+	if err := b.jobMgr.Start(ctx); err != nil {
+		return err
+	}
+	if err := b.jobMgr.RunProducers(ctx); err != nil {
+		return err
+	}
 
 	if b.svr == nil {
 		b.Logger().Info("no HTTP server registered, skipping")
@@ -73,16 +83,23 @@ func (b *BaseApp) Start(ctx context.Context) error {
 		go b.svr.Start(ctx)
 	}
 
+	b.Logger().Info("successfully started")
 	return nil
 }
 
-// Stop stops the baseapp.
-func (b *BaseApp) Stop() {
+// Stop stops the baseapp, returning the errors of tearing down the jobs.
+func (b *BaseApp) Stop() error {
 	b.Logger().Info("attempting to stop")
-	defer b.Logger().Info("successfully stopped")
 
-	b.jobMgr.Stop()
+	err := b.jobMgr.Stop()
 	if b.svr != nil {
+		// Only closes the server if it was started, i.e. not if starting the app failed.
 		b.svr.Stop()
 	}
+	if err != nil {
+		return err
+	}
+
+	b.Logger().Info("successfully stopped")
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
 	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/tools/election"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	ethdb "github.com/ethereum/go-ethereum/ethdb"
@@ -17,10 +18,21 @@ import (
 type AppBuilder struct {
 	appName   string
 	jobs      []job.Basic
+	jobMgrCfg JobManagerConfig
 	db        ethdb.KeyValueStore
 	ethClient eth.Client
 	svr       *server.Server
 	metrics   telemetry.Metrics
+
+	// adminToken, if set, is the bearer token of the admin API of the job manager, which is then
+	// served on the HTTP server.
+	adminToken string
+
+	// leaderLock is the lock for leader election, overriding the job manager config's backend.
+	leaderLock election.Lock
+
+	// pipelines route the results of jobs into their downstream jobs.
+	pipelines []*job.Pipeline
 }
 
 // NewAppBuilder creates a new app builder.
@@ -42,6 +54,24 @@ func (ab *AppBuilder) RegisterJob(job job.Basic) {
 	ab.jobs = append(ab.jobs, job)
 }
 
+// RegisterPipeline registers a pipeline that routes the results of the registered jobs into their
+// downstream jobs, see `job.Pipeline`.
+func (ab *AppBuilder) RegisterPipeline(p *job.Pipeline) {
+	ab.pipelines = append(ab.pipelines, p)
+}
+
+// RegisterJobManagerConfig registers the worker pool configs used by the job manager.
+func (ab *AppBuilder) RegisterJobManagerConfig(cfg JobManagerConfig) {
+	ab.jobMgrCfg = cfg
+}
+
+// RegisterLeaderLock registers the lock that the replicas of the app campaign for leadership with,
+// which enables leader election for the singleton jobs. This overrides the backend of the leader
+// election config, e.g. for a lock backed by a client the app already has.
+func (ab *AppBuilder) RegisterLeaderLock(lock election.Lock) {
+	ab.leaderLock = lock
+}
+
 // RegisterDB registers the db.
 func (ab *AppBuilder) RegisterDB(db ethdb.KeyValueStore) {
 	ab.db = db
@@ -88,6 +118,20 @@ func (ab *AppBuilder) RegisterMiddleware(m server.Middleware) error {
 	return nil
 }
 
+// RegisterAdminHandler registers the admin API of the job manager on the HTTP server, only serving
+// requests that carry the given bearer token, see `JobManager.AdminHandler`.
+func (ab *AppBuilder) RegisterAdminHandler(token string) error {
+	if ab.svr == nil {
+		return errors.New("must enable the HTTP server to register the admin handler")
+	}
+	if token == "" {
+		return errors.New("must set a token to register the admin handler")
+	}
+
+	ab.adminToken = token
+	return nil
+}
+
 // RegisterPrometheusTelemetry registers a Prometheus metrics HTTP server.
 func (ab *AppBuilder) RegisterPrometheusTelemetry() error {
 	if ab.svr == nil {
@@ -107,13 +151,26 @@ func (ab *AppBuilder) RegisterEthClient(ethClient eth.Client) {
 func (ab *AppBuilder) BuildApp(
 	logger log.Logger,
 ) *BaseApp {
-	return New(
+	app := New(
 		ab.appName,
 		logger,
 		ab.ethClient,
 		ab.jobs,
+		ab.jobMgrCfg,
 		ab.db,
 		ab.svr,
 		ab.metrics,
 	)
+	for _, p := range ab.pipelines {
+		app.jobMgr.addPipeline(p)
+	}
+	if ab.leaderLock != nil {
+		app.jobMgr.setLeaderLock(ab.leaderLock)
+	}
+	if ab.adminToken != "" {
+		ab.svr.RegisterHandler(&server.Handler{
+			Path: AdminPath, Handler: app.jobMgr.AdminHandler(ab.adminToken),
+		})
+	}
+	return app
 }
@@ -0,0 +1,164 @@
+package baseapp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// checkpointTracker keeps track of the checkpoints of a job's inputs in the order they were
+// received, saving the checkpoint up to which all inputs are executed successfully. Once an input
+// fails, the checkpoint is held before it, so that it is executed again after a restart.
+type checkpointTracker struct {
+	store  *job.CheckpointStore
+	jobKey string
+	logger log.Logger
+
+	// source is the checkpointed job, whose executions are tracked directly (instead of the inputs
+	// it is submitted) if it has a producer of its own.
+	source job.Checkpointed
+	direct bool
+
+	mu      sync.Mutex
+	pending []*pendingCheckpoint
+	held    bool
+}
+
+// pendingCheckpoint is the checkpoint of an input, which is reached once the input is executed.
+type pendingCheckpoint struct {
+	cp   job.Checkpoint
+	done bool
+}
+
+// track adds the checkpoint of a received input, returning the function to call with the error
+// of the input's execution once it is executed.
+func (ct *checkpointTracker) track(cp job.Checkpoint) func(error) {
+	pc := &pendingCheckpoint{cp: cp}
+	ct.mu.Lock()
+	if !ct.held {
+		ct.pending = append(ct.pending, pc)
+	}
+	ct.mu.Unlock()
+
+	return func(err error) {
+		ct.mu.Lock()
+		defer ct.mu.Unlock()
+		if ct.held {
+			return
+		}
+		if err != nil {
+			ct.logger.Warn(
+				"execution failed, holding checkpoint until restart", "job", ct.jobKey, "err", err,
+			)
+			ct.held, ct.pending = true, nil
+			return
+		}
+		pc.done = true
+
+		// Advance past all executed inputs, saving the checkpoint of the last one.
+		var reached *pendingCheckpoint
+		for len(ct.pending) > 0 && ct.pending[0].done {
+			reached, ct.pending = ct.pending[0], ct.pending[1:]
+		}
+		if reached == nil {
+			return
+		}
+		if err := ct.store.Save(ct.jobKey, reached.cp); err != nil {
+			ct.logger.Error("failed to save checkpoint", "job", ct.jobKey, "err", err)
+		}
+	}
+}
+
+// restoreCheckpoint gives the given job its saved checkpoint and keeps track of the checkpoints of
+// its inputs from then on, if the job is checkpointed and the app has a DB.
+func (jm *JobManager) restoreCheckpoint(ctx context.Context, j job.Basic) error {
+	cj, ok := j.(job.Checkpointed)
+	if !ok || jm.ctxFactory.db == nil {
+		return nil
+	}
+
+	store := job.NewCheckpointStore(jm.ctxFactory.db)
+	cp, err := store.Load(j.RegistryKey())
+	if err != nil {
+		return fmt.Errorf("load checkpoint: %w", err)
+	}
+	if err = cj.RestoreCheckpoint(ctx, cp); err != nil {
+		return fmt.Errorf("restore checkpoint: %w", err)
+	}
+
+	jm.mu.Lock()
+	jm.checkpoints[j.RegistryKey()] = &checkpointTracker{
+		store:  store,
+		jobKey: j.RegistryKey(),
+		logger: jm.ctxFactory.logger,
+		source: cj,
+		direct: job.WrapJob(j) != nil,
+	}
+	jm.mu.Unlock()
+	return nil
+}
+
+// ExecutionStarted implements jobtypes.Observer.
+func (jm *JobManager) ExecutionStarted(jobKey string) {
+	jm.jobStates.ExecutionStarted(jobKey)
+}
+
+// ExecutionFinished implements jobtypes.Observer. Checkpointed jobs with a producer of their own
+// (e.g. polling jobs) submit their inputs themselves, so the checkpoints of their inputs are
+// tracked as they are executed.
+func (jm *JobManager) ExecutionFinished(jobKey string, input any, err error) {
+	jm.jobStates.ExecutionFinished(jobKey, input, err)
+
+	jm.mu.RLock()
+	tracker := jm.checkpoints[jobKey]
+	jm.mu.RUnlock()
+	if tracker == nil || !tracker.direct {
+		return
+	}
+	if cp, ok := tracker.source.CheckpointOf(input); ok {
+		tracker.track(cp)(err)
+	}
+}
+
+// submitInput submits the execution of the given input to the job's executor. If the source of
+// the input (i.e. the job or its subscription) is checkpointed, the input's checkpoint is reached
+// once it is executed successfully, or skipped if the job is paused.
+func (jm *JobManager) submitInput(
+	ctx context.Context, executor job.WorkerPool, j job.Basic, source job.Basic, input any,
+) {
+	payload := jobtypes.NewPayload(ctx, j, input)
+	execute := payload.Execute
+
+	jm.mu.RLock()
+	tracker := jm.checkpoints[j.RegistryKey()]
+	jm.mu.RUnlock()
+	cj, ok := source.(job.Checkpointed)
+	if tracker == nil || !ok {
+		executor.Submit(execute)
+		return
+	}
+	cp, ok := cj.CheckpointOf(input)
+	if !ok {
+		executor.Submit(execute)
+		return
+	}
+
+	// Skipped inputs of a paused job must reach their checkpoint too, so the task checks whether
+	// the job is paused itself instead of being dropped by the executor.
+	done := tracker.track(cp)
+	paused := func() bool { return false }
+	if pe, isPausable := executor.(*pausableExecutor); isPausable {
+		executor, paused = pe.WorkerPool, pe.state.paused.Load
+	}
+	executor.Submit(func() {
+		if paused() {
+			done(nil)
+			return
+		}
+		done(payload.Run())
+	})
+}
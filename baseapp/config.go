@@ -0,0 +1,36 @@
+package baseapp
+
+import (
+	"time"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/tools/election"
+	"github.com/berachain/offchain-sdk/worker"
+)
+
+// JobManagerConfig is the configuration for the job manager's worker pools and subscriptions. Any
+// field left unset falls back to the job manager's default.
+type JobManagerConfig struct {
+	// Producers configures the pool of workers that run the job producers. Every registered job
+	// occupies a producer worker for as long as it runs, and another pool of the same size is
+	// added whenever all workers are taken. Defaults to sizing the pool to the number of jobs
+	// registered at startup.
+	Producers worker.PoolConfig
+
+	// Executors configures the pool of workers that execute the jobs. Defaults to
+	// `worker.DefaultPoolConfig()`.
+	Executors worker.PoolConfig
+
+	// Subscriptions configures how subscription jobs are resubscribed, unless overridden by the
+	// job's own subscription policy. Defaults to a stale timeout of an hour and retrying forever,
+	// with a backoff doubling from 1s up to 2m.
+	Subscriptions jobtypes.SubscriptionPolicy
+
+	// TeardownTimeout bounds the teardown of every job on stop. Defaults to 30s.
+	TeardownTimeout time.Duration
+
+	// LeaderElection configures the election of a leader among the replicas of the app, which is
+	// the only replica that runs the singleton jobs. Disabled by default, in which case singleton
+	// jobs are run like any other job.
+	LeaderElection election.Config
+}
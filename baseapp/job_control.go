@@ -0,0 +1,265 @@
+package baseapp
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+)
+
+var (
+	// ErrJobNotFound is returned when operating on a job that is not registered.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobsNotRunning is returned when running a job before the job producers are started.
+	ErrJobsNotRunning = errors.New("jobs are not running")
+	// ErrRunNowUnsupported is returned when running a job right away whose executions need an
+	// input, e.g. a subscription, scheduled or block interval job.
+	ErrRunNowUnsupported = errors.New("job cannot be run without an input")
+)
+
+// JobStatus is the status of a registered job.
+type JobStatus struct {
+	// Job is the registry key of the job.
+	Job string `json:"job"`
+	// Paused is whether the job is paused, i.e. its executions are skipped.
+	Paused bool `json:"paused"`
+	// Skipped is whether the job is an optional job that failed to start and thus is not run.
+	Skipped bool `json:"skipped"`
+	// Standby is whether the job is a singleton job that is not run, since this replica is not
+	// the leader.
+	Standby bool `json:"standby"`
+	// RunCount is the number of finished executions of the job.
+	RunCount uint64 `json:"runCount"`
+	// InFlight is the number of executions of the job currently running.
+	InFlight int64 `json:"inFlight"`
+	// LastRun is when the last execution of the job finished.
+	LastRun *time.Time `json:"lastRun,omitempty"`
+	// LastError is the error of the last failed execution of the job.
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorAt is when the last failed execution of the job finished.
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// jobState keeps track of the status of a job.
+type jobState struct {
+	paused   atomic.Bool
+	inFlight atomic.Int64
+
+	mu          sync.Mutex
+	runCount    uint64
+	lastRun     time.Time
+	lastErr     error
+	lastErrorAt time.Time
+}
+
+// jobStates keeps track of the status of all jobs, as the observer of their executions.
+type jobStates struct {
+	mu    sync.RWMutex
+	byKey map[string]*jobState
+}
+
+var _ jobtypes.Observer = (*jobStates)(nil)
+
+// newJobStates creates the states for the given jobs.
+func newJobStates(jobs []job.Basic) *jobStates {
+	js := &jobStates{byKey: make(map[string]*jobState, len(jobs))}
+	for _, j := range jobs {
+		js.byKey[j.RegistryKey()] = &jobState{}
+	}
+	return js
+}
+
+// add adds the state of the job with the given key.
+func (js *jobStates) add(jobKey string) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	js.byKey[jobKey] = &jobState{}
+}
+
+// remove removes the state of the job with the given key.
+func (js *jobStates) remove(jobKey string) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	delete(js.byKey, jobKey)
+}
+
+// get returns the state of the job with the given key, if it is registered.
+func (js *jobStates) get(jobKey string) (*jobState, error) {
+	js.mu.RLock()
+	defer js.mu.RUnlock()
+	state, ok := js.byKey[jobKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobKey)
+	}
+	return state, nil
+}
+
+// ExecutionStarted implements jobtypes.Observer.
+func (js *jobStates) ExecutionStarted(jobKey string) {
+	if state, err := js.get(jobKey); err == nil {
+		state.inFlight.Add(1)
+	}
+}
+
+// ExecutionFinished implements jobtypes.Observer.
+func (js *jobStates) ExecutionFinished(jobKey string, _ any, err error) {
+	state, getErr := js.get(jobKey)
+	if getErr != nil {
+		return
+	}
+	state.inFlight.Add(-1)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.runCount++
+	state.lastRun = time.Now()
+	if err != nil {
+		state.lastErr, state.lastErrorAt = err, state.lastRun
+	}
+}
+
+// status returns the status of the job with the given key.
+func (state *jobState) status(jobKey string, skipped, standby bool) *JobStatus {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	status := &JobStatus{
+		Job:      jobKey,
+		Paused:   state.paused.Load(),
+		Skipped:  skipped,
+		Standby:  standby,
+		RunCount: state.runCount,
+		InFlight: state.inFlight.Load(),
+	}
+	if !state.lastRun.IsZero() {
+		lastRun := state.lastRun
+		status.LastRun = &lastRun
+	}
+	if state.lastErr != nil {
+		lastErrorAt := state.lastErrorAt
+		status.LastError, status.LastErrorAt = state.lastErr.Error(), &lastErrorAt
+	}
+	return status
+}
+
+// pausableExecutor is a job's executor that skips all submitted payloads while the job is paused.
+type pausableExecutor struct {
+	job.WorkerPool
+	state *jobState
+}
+
+// Submit submits the task, unless the job is paused.
+func (pe *pausableExecutor) Submit(task func()) {
+	if !pe.state.paused.Load() {
+		pe.WorkerPool.Submit(task)
+	}
+}
+
+// TrySubmit submits the task without blocking, unless the job is paused, returning whether it was
+// submitted or skipped.
+func (pe *pausableExecutor) TrySubmit(task func()) bool {
+	return pe.state.paused.Load() || trySubmit(pe.WorkerPool, task)
+}
+
+// SubmitAndWait submits the task and waits for it to finish, unless the job is paused.
+func (pe *pausableExecutor) SubmitAndWait(task func()) {
+	if !pe.state.paused.Load() {
+		pe.WorkerPool.SubmitAndWait(task)
+	}
+}
+
+// Pause pauses the job with the given key. The producer of a paused job keeps running, but all of
+// its executions are skipped until the job is resumed.
+func (jm *JobManager) Pause(jobKey string) error {
+	state, err := jm.jobStates.get(jobKey)
+	if err != nil {
+		return err
+	}
+	state.paused.Store(true)
+	jm.ctxFactory.logger.Info("paused job", "job", jobKey)
+	return nil
+}
+
+// Resume resumes the paused job with the given key.
+func (jm *JobManager) Resume(jobKey string) error {
+	state, err := jm.jobStates.get(jobKey)
+	if err != nil {
+		return err
+	}
+	state.paused.Store(false)
+	jm.ctxFactory.logger.Info("resumed job", "job", jobKey)
+	return nil
+}
+
+// RunNow executes the job with the given key once, right away and with a nil input, regardless of
+// whether the job is paused. Only polling and conditional jobs can be run, since the executions
+// of all other jobs need an input, e.g. the log of a subscription job.
+func (jm *JobManager) RunNow(jobKey string) error {
+	jm.mu.RLock()
+	j, ctx := jm.jobRegistry.Get(jobKey), jm.runCtx
+	jm.mu.RUnlock()
+	if j == nil {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, jobKey)
+	}
+	if !runnableNow(j) {
+		return fmt.Errorf("%w: %s", ErrRunNowUnsupported, jobKey)
+	}
+	if ctx == nil {
+		return ErrJobsNotRunning
+	}
+
+	jm.ctxFactory.logger.Info("running job now", "job", jobKey)
+	executor := jm.executorFor(jobKey)
+	if pe, ok := executor.(*pausableExecutor); ok {
+		executor = pe.WorkerPool
+	}
+	executor.Submit(jobtypes.NewPayload(ctx, j, nil).Execute)
+	return nil
+}
+
+// runnableNow returns whether the given job can be executed with a nil input, i.e. it is a polling
+// or conditional job. Custom producers may pass any input to their job, so are not runnable.
+func runnableNow(j job.Basic) bool {
+	if _, ok := j.(job.HasProducer); ok {
+		return false
+	}
+	_, ok := j.(job.Polling)
+	return ok
+}
+
+// Status returns the status of the job with the given key.
+func (jm *JobManager) Status(jobKey string) (*JobStatus, error) {
+	state, err := jm.jobStates.get(jobKey)
+	if err != nil {
+		return nil, err
+	}
+	jm.mu.RLock()
+	_, skipped := jm.skippedJobs[jobKey]
+	standby := jm.standby(jm.jobRegistry.Get(jobKey))
+	jm.mu.RUnlock()
+	return state.status(jobKey, skipped, standby), nil
+}
+
+// Statuses returns the statuses of all jobs, in the order they were registered.
+func (jm *JobManager) Statuses() ([]*JobStatus, error) {
+	jm.mu.RLock()
+	orderedJobs, err := jm.jobRegistry.IterateInOrder()
+	jm.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*JobStatus, 0, len(orderedJobs.Keys()))
+	for _, jobKey := range orderedJobs.Keys() {
+		status, err := jm.Status(jobKey)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
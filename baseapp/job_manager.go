@@ -2,12 +2,18 @@ package baseapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/tools/election"
 	sdk "github.com/berachain/offchain-sdk/types"
 	"github.com/berachain/offchain-sdk/worker"
 )
@@ -19,6 +25,8 @@ const (
 
 	executorName     = "job-executor"
 	executorPromName = "job_executor"
+
+	defaultTeardownTimeout = 30 * time.Second
 )
 
 // JobManager handles the job and worker lifecycle.
@@ -29,28 +37,117 @@ type JobManager struct {
 	// ctxFactory is used to create new sdk.Context(s).
 	ctxFactory *contextFactory
 
-	// Job producers are a pool of workers that produce jobs. These workers
+	// Job producers are pools of workers that produce jobs. These workers
 	// run in the background and produce jobs that are then consumed by the
-	// job executors.
-	producerCfg  *worker.PoolConfig
-	jobProducers *worker.Pool
+	// job executors. Since pools cannot be resized, another pool is added
+	// whenever all producer workers are taken by the jobs' producers.
+	producerCfg   *worker.PoolConfig
+	jobProducers  []*worker.Pool
+	producerLoads map[*worker.Pool]uint16
 
 	// Job executors are a pool of workers that execute jobs. These workers
 	// are fed jobs by the job producers.
 	executorCfg  *worker.PoolConfig
 	jobExecutors *worker.Pool
 
+	// Jobs with an execution policy are routed through their own executor, either a dedicated
+	// pool or a concurrency-limited view of the shared job executors.
+	jobExecutorsByKey map[string]*jobExecutor
+
+	// subscriptionCfg is the default policy for resubscribing subscription jobs.
+	subscriptionCfg *jobtypes.SubscriptionPolicy
+
+	// teardownTimeout bounds the teardown of every job.
+	teardownTimeout time.Duration
+
+	// skippedJobs are the optional jobs that failed to start and thus are not run.
+	skippedJobs map[string]struct{}
+
+	// jobStates keeps track of the status of every job, and whether it is paused.
+	jobStates *jobStates
+
+	// poolCtx is the context that the worker pools are run with, set once started.
+	poolCtx context.Context
+	// runCtx is the context that the jobs are run with, set once the producers are started.
+	runCtx *sdk.Context
+	// jobCancels cancels the producer of every running job, whose context is kept in jobCtxs. The
+	// job's channel in jobDones is closed once its producer returns.
+	jobCancels map[string]context.CancelFunc
+	jobCtxs    map[string]*sdk.Context
+	jobDones   map[string]chan struct{}
+
+	// pipelines route the results of jobs into their downstream jobs.
+	pipelines []*job.Pipeline
+
+	// checkpoints keeps track of the checkpoints of every running checkpointed job.
+	checkpoints map[string]*checkpointTracker
+
+	// If leader election is enabled, the elector campaigns for leadership with the leader lock,
+	// and the singleton jobs are only run while leading. singletonMu serializes starting and
+	// stopping the singleton jobs on leadership changes.
+	leaderCfg     *election.Config
+	leaderLock    election.Lock
+	elector       *election.Elector
+	electorCancel context.CancelFunc
+	electorDone   chan struct{}
+	singletonMu   sync.Mutex
+
+	// mu guards the registry and the per-job state above, since jobs can be registered and
+	// deregistered at runtime.
+	mu sync.RWMutex
+
 	// TODO: introduce telemetry.Metrics to this struct and the BaseApp.
 }
 
-// NewManager creates a new manager.
+// jobExecutor is the executor of a job with an execution policy.
+type jobExecutor struct {
+	job.WorkerPool
+
+	// dedicated is the job's dedicated pool, if it has one.
+	dedicated *worker.Pool
+	// limited is the concurrency-limited view of the pool, if the job's concurrency is capped.
+	limited *worker.LimitedPool
+}
+
+// TrySubmit submits the task without blocking, returning whether it was submitted.
+func (je *jobExecutor) TrySubmit(task func()) bool {
+	return trySubmit(je.WorkerPool, task)
+}
+
+// close stops the job's dedicated pool, waiting for its in-flight tasks, and releases the limited
+// view of the pool.
+func (je *jobExecutor) close() {
+	if je.dedicated != nil {
+		je.dedicated.StopAndWait()
+	}
+	if je.limited != nil {
+		je.limited.Close()
+	}
+}
+
+// NewManager creates a new manager. Any pool settings left unset in the given config are filled
+// in with the defaults.
 func NewManager(
 	jobs []job.Basic,
+	cfg JobManagerConfig,
 	ctxFactory *contextFactory,
 ) *JobManager {
 	m := &JobManager{
-		jobRegistry: job.NewRegistry(),
-		ctxFactory:  ctxFactory,
+		jobRegistry:       job.NewRegistry(),
+		ctxFactory:        ctxFactory,
+		producerLoads:     make(map[*worker.Pool]uint16),
+		jobExecutorsByKey: make(map[string]*jobExecutor),
+		teardownTimeout:   cfg.TeardownTimeout,
+		skippedJobs:       make(map[string]struct{}),
+		jobStates:         newJobStates(jobs),
+		jobCancels:        make(map[string]context.CancelFunc),
+		jobCtxs:           make(map[string]*sdk.Context),
+		jobDones:          make(map[string]chan struct{}),
+		checkpoints:       make(map[string]*checkpointTracker),
+		leaderCfg:         cfg.LeaderElection.WithDefaults(),
+	}
+	if m.teardownTimeout == 0 {
+		m.teardownTimeout = defaultTeardownTimeout
 	}
 
 	// Register all supplied jobs with the manager.
@@ -60,23 +157,25 @@ func NewManager(
 		}
 	}
 
-	// TODO: read pool configs from the config file.
-
 	// Setup the producer worker pool.
 	jobCount := uint16(m.jobRegistry.Count()) //nolint:gosec // safe to convert.
-	m.producerCfg = &worker.PoolConfig{
+	m.producerCfg = cfg.Producers.WithDefaults(&worker.PoolConfig{
 		Name:             producerName,
 		PrometheusPrefix: producerPromName,
 		MinWorkers:       jobCount,
 		MaxWorkers:       jobCount + 1,
 		ResizingStrategy: producerResizeStrategy,
 		MaxQueuedJobs:    jobCount,
-	}
+	})
 
 	// Setup the executor worker pool.
-	m.executorCfg = worker.DefaultPoolConfig()
-	m.executorCfg.Name = executorName
-	m.executorCfg.PrometheusPrefix = executorPromName
+	defaultExecutorCfg := worker.DefaultPoolConfig()
+	defaultExecutorCfg.Name = executorName
+	defaultExecutorCfg.PrometheusPrefix = executorPromName
+	m.executorCfg = cfg.Executors.WithDefaults(defaultExecutorCfg)
+
+	// Setup the default subscription policy.
+	m.subscriptionCfg = cfg.Subscriptions.WithDefaults(defaultSubscriptionPolicy())
 
 	// Return the manager.
 	return m
@@ -87,84 +186,606 @@ func (jm *JobManager) Logger(ctx context.Context) log.Logger {
 	return sdk.UnwrapContext(ctx).Logger().With("namespace", "job-manager")
 }
 
-// Start calls `Setup` on the jobs in the registry as well as spins up the worker pools.
-func (jm *JobManager) Start(ctx context.Context) {
+// Start validates the worker pool configs and spins up the worker pools.
+func (jm *JobManager) Start(ctx context.Context) error {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+
+	if err := jm.validatePoolConfigs(); err != nil {
+		return err
+	}
+	if _, err := jm.sortedJobs(); err != nil {
+		return err
+	}
+	if err := jm.setupElector(); err != nil {
+		return err
+	}
+
 	// We pass in the context in order to handle cancelling the workers. We pass the
 	// standard go context and not an sdk.Context here since the context here is just used
 	// for cancelling the workers on shutdown.
-	logger := jm.ctxFactory.logger
-	jm.jobExecutors = worker.NewPool(ctx, logger, jm.executorCfg)
-	jm.jobProducers = worker.NewPool(ctx, logger, jm.producerCfg)
+	jm.poolCtx = ctx
+	jm.jobExecutors = worker.NewPool(ctx, jm.ctxFactory.logger, jm.executorCfg)
+	jm.addProducerPool(jm.producerCfg)
+
+	// Build the executors for the jobs that define an execution policy.
+	for _, j := range jm.jobRegistry.Iterate() {
+		jm.setupJobExecutor(j)
+	}
+	return nil
+}
+
+// validatePoolConfigs ensures the producer and executor pool configs are usable.
+func (jm *JobManager) validatePoolConfigs() error {
+	if err := jm.producerCfg.Validate(); err != nil {
+		return err
+	}
+	if err := jm.executorCfg.Validate(); err != nil {
+		return err
+	}
+
+	// Validate the dedicated executor pool of every job that has one.
+	for _, j := range jm.jobRegistry.Iterate() {
+		if err := jm.validateJobExecutor(j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateJobExecutor ensures the dedicated executor pool of the given job is usable, if it has
+// one.
+func (jm *JobManager) validateJobExecutor(j job.Basic) error {
+	if pj, ok := j.(job.HasExecutionPolicy); ok {
+		if policy := pj.ExecutionPolicy(); policy != nil && policy.Pool != nil {
+			if err := jm.dedicatedPoolConfig(j.RegistryKey(), policy).Validate(); err != nil {
+				return fmt.Errorf("job %s: %w", j.RegistryKey(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// setupJobExecutor builds the executor for the given job, if it defines an execution policy.
+func (jm *JobManager) setupJobExecutor(j job.Basic) {
+	if pj, ok := j.(job.HasExecutionPolicy); ok {
+		if policy := pj.ExecutionPolicy(); policy != nil {
+			jm.jobExecutorsByKey[j.RegistryKey()] = jm.newJobExecutor(j.RegistryKey(), policy)
+		}
+	}
+}
+
+// newJobExecutor builds the executor for a job from its execution policy. A dedicated pool takes
+// precedence over the shared executor pool, and either can be capped by the max concurrency.
+func (jm *JobManager) newJobExecutor(
+	jobKey string, policy *jobtypes.ExecutionPolicy,
+) *jobExecutor {
+	var (
+		executor   = &jobExecutor{WorkerPool: jm.jobExecutors}
+		promPrefix = executorPromName + "_" + promSafeName(jobKey)
+	)
+	if policy.Pool != nil {
+		executor.dedicated = worker.NewPool(
+			jm.poolCtx, jm.ctxFactory.logger, jm.dedicatedPoolConfig(jobKey, policy),
+		)
+		executor.WorkerPool = executor.dedicated
+	}
+	if policy.MaxConcurrency > 0 {
+		pool := jm.jobExecutors
+		if executor.dedicated != nil {
+			pool = executor.dedicated
+		}
+		executor.limited = worker.NewLimitedPool(pool, policy.MaxConcurrency, promPrefix)
+		executor.WorkerPool = executor.limited
+	}
+	return executor
+}
+
+// dedicatedPoolConfig returns the config of a job's dedicated executor pool, falling back to the
+// shared executor pool's config for any unset fields.
+func (jm *JobManager) dedicatedPoolConfig(
+	jobKey string, policy *jobtypes.ExecutionPolicy,
+) *worker.PoolConfig {
+	defaults := *jm.executorCfg
+	defaults.Name = executorName + "-" + jobKey
+	defaults.PrometheusPrefix = executorPromName + "_" + promSafeName(jobKey)
+	return policy.Pool.WithDefaults(&defaults)
+}
+
+// executorFor returns the worker pool that executes the payloads of the job with the given key,
+// which skips the payloads while the job is paused.
+func (jm *JobManager) executorFor(jobKey string) job.WorkerPool {
+	jm.mu.RLock()
+	defer jm.mu.RUnlock()
+
+	var executor job.WorkerPool = jm.jobExecutors
+	if je, ok := jm.jobExecutorsByKey[jobKey]; ok {
+		executor = je
+	}
+	state, err := jm.jobStates.get(jobKey)
+	if err != nil {
+		return executor
+	}
+	return &pausableExecutor{WorkerPool: executor, state: state}
+}
+
+// promUnsafeChars matches the characters that are not allowed in Prometheus metric names.
+var promUnsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// promSafeName converts the given name to be usable in a Prometheus metric name.
+func promSafeName(name string) string {
+	return promUnsafeChars.ReplaceAllString(name, "_")
+}
+
+// addProducerPool adds a producer pool with the given config.
+func (jm *JobManager) addProducerPool(cfg *worker.PoolConfig) *worker.Pool {
+	pool := worker.NewPool(jm.poolCtx, jm.ctxFactory.logger, cfg)
+	jm.jobProducers = append(jm.jobProducers, pool)
+	jm.producerLoads[pool] = 0
+	return pool
+}
+
+// producerPool returns a producer pool with a free worker, for a producer that runs until its job
+// is deregistered. If all producer pools are full, another pool (of the same size as the first
+// one) is added. It must be called with the lock held.
+func (jm *JobManager) producerPool() *worker.Pool {
+	for _, pool := range jm.jobProducers {
+		if jm.producerLoads[pool] < jm.producerCfg.MaxWorkers {
+			return pool
+		}
+	}
+
+	cfg := *jm.producerCfg
+	cfg.Name = fmt.Sprintf("%s-%d", producerName, len(jm.jobProducers))
+	cfg.PrometheusPrefix = fmt.Sprintf("%s_%d", producerPromName, len(jm.jobProducers))
+	jm.ctxFactory.logger.Info(
+		"producer pools are full, adding a producer pool", "pool", cfg.Name,
+		"workers", cfg.MaxWorkers,
+	)
+	return jm.addProducerPool(&cfg)
+}
+
+// submitProducer submits the given producer task to a producer pool with a free worker, which is
+// freed up again once the task returns, upon which the given channel is closed.
+func (jm *JobManager) submitProducer(task func(), done chan struct{}) {
+	jm.mu.Lock()
+	pool := jm.producerPool()
+	jm.producerLoads[pool]++
+	jm.mu.Unlock()
+
+	pool.Submit(func() {
+		defer func() {
+			jm.mu.Lock()
+			jm.producerLoads[pool]--
+			jm.mu.Unlock()
+			close(done)
+		}()
+		task()
+	})
+}
+
+// waitProducer waits for the producer of a cancelled job to return, so that it no longer submits
+// payloads, before the job's executor is stopped and the job is torn down. The wait is bounded by
+// the teardown timeout.
+func (jm *JobManager) waitProducer(jobKey string, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(jm.teardownTimeout):
+		jm.ctxFactory.logger.Warn("timed out waiting for the job producer to stop", "job", jobKey)
+	}
 }
 
 // Stop calls `Teardown` on the jobs in the registry as well as shut's down all the worker pools.
-func (jm *JobManager) Stop() {
-	var wg sync.WaitGroup
+// Every teardown is bounded by the teardown timeout, and all teardown errors are returned
+// together.
+func (jm *JobManager) Stop() error {
+	var (
+		wg          sync.WaitGroup
+		teardownErr error
+	)
+
+	// Give up leadership first, which stops and tears down the singleton jobs.
+	jm.stopElector()
+
+	// Stop all producers.
+	jm.mu.Lock()
+	for _, cancel := range jm.jobCancels {
+		cancel()
+	}
+	jm.mu.Unlock()
 
 	// Shutdown producers.
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		jm.jobProducers.Stop()
-		jm.jobProducers = nil
+		for _, pool := range jm.jobProducers {
+			pool.Stop()
+		}
 	}()
 
 	// Shutdown executors and call Teardown() if a job has one.
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		jm.jobExecutors.StopAndWait()
-		for _, j := range jm.jobRegistry.Iterate() {
-			if tj, ok := j.(job.HasTeardown); ok {
-				if err := tj.Teardown(); err != nil {
-					panic(err)
-				}
-			}
+		for _, executor := range jm.jobExecutorsByKey {
+			executor.close()
+		}
+		if jm.jobExecutors != nil {
+			jm.jobExecutors.StopAndWait()
 		}
-		jm.jobExecutors = nil
+		teardownErr = jm.teardownJobs()
 	}()
 
 	// Wait for both to finish.
 	wg.Wait()
 
+	jm.jobProducers = nil
+	jm.jobExecutors = nil
+	jm.jobExecutorsByKey = make(map[string]*jobExecutor)
+	jm.jobCancels = make(map[string]context.CancelFunc)
+	jm.jobCtxs = make(map[string]*sdk.Context)
+	jm.jobDones = make(map[string]chan struct{})
+	jm.runCtx = nil
+
 	// Close the metrics.
 	if err := jm.ctxFactory.metrics.Close(); err != nil {
 		jm.ctxFactory.logger.Error("failed to close metrics", "err", err)
 	}
+	return teardownErr
 }
 
-// RunProducers sets up each job and runs its producer.
-func (jm *JobManager) RunProducers(gctx context.Context) {
-	ctx := jm.ctxFactory.NewSDKContext(gctx)
-
-	// Load all jobs in registry in the order they were registered.
+// sortedJobs returns all registered jobs such that every job comes after its dependencies and the
+// jobs it routes its results to, and otherwise in the order they were registered. It must be
+// called with the lock held.
+func (jm *JobManager) sortedJobs() ([]job.Basic, error) {
 	orderedJobs, err := jm.jobRegistry.IterateInOrder()
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	jobs := make([]job.Basic, 0, orderedJobs.Len())
+	for el := orderedJobs.Front(); el != nil; el = el.Next() {
+		jobs = append(jobs, el.Value)
+	}
+	return job.SortByDependencies(jobs, jm.pipelines...)
+}
+
+// teardownJobs calls `Teardown` on every job that has one and was set up, i.e. not on the jobs
+// that failed to start (or were never started) nor on the singleton jobs that are not run. Jobs
+// are torn down before the jobs they depend on.
+func (jm *JobManager) teardownJobs() error {
+	sorted, err := jm.sortedJobs()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := len(sorted) - 1; i >= 0; i-- {
+		j := sorted[i]
+		jm.mu.RLock()
+		_, started := jm.jobCancels[j.RegistryKey()]
+		jm.mu.RUnlock()
+		if !started {
+			continue
+		}
+		if err := jm.teardown(j); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: teardown: %w", j.RegistryKey(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// teardown calls `Teardown` on the given job, if it has one, with a context bounded by the
+// teardown timeout. If the job does not return in time, it is given up on.
+func (jm *JobManager) teardown(j job.Basic) error {
+	ctx, cancel := context.WithTimeout(context.Background(), jm.teardownTimeout)
+	defer cancel()
+
+	var teardownFn func() error
+	switch tj := j.(type) {
+	case job.HasTeardownContext:
+		teardownFn = func() error { return tj.Teardown(jm.ctxFactory.NewSDKContext(ctx)) }
+	case job.HasTeardown:
+		teardownFn = tj.Teardown
+	default:
+		return nil
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- teardownFn()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunProducers sets up each job and runs its producer, after the jobs it depends on. A job that
+// fails to be set up, is of an unknown type, or depends on a job that failed, is not run. If the
+// job is optional the error is logged, otherwise the errors of all such jobs are returned
+// together. If leader election is enabled, the singleton jobs are instead run once elected.
+func (jm *JobManager) RunProducers(gctx context.Context) error {
+	// The job manager observes all executions to keep track of the status and checkpoints of the
+	// jobs, and the results of all executions are routed through the pipelines.
+	ctx := jm.ctxFactory.NewSDKContext(jobtypes.ContextWithRouter(
+		jobtypes.ContextWithObserver(gctx, jm), jm,
+	))
+
+	// Load all jobs in registry in the order they were registered, with their dependencies first.
+	jm.mu.Lock()
+	jm.runCtx = ctx
+	sorted, err := jm.sortedJobs()
+	jm.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs   []error
+		failed = make(map[string]struct{})
+	)
+	for _, j := range sorted {
+		if jm.standby(j) {
+			continue
+		}
+		jobID := j.RegistryKey()
+		err = failedDependency(j, failed)
+		if err == nil {
+			err = jm.runProducer(ctx, j)
+		}
+		if err == nil {
+			continue
+		}
+		failed[jobID] = struct{}{}
+
+		if oj, ok := j.(job.Optional); ok && oj.Optional() {
+			jm.Logger(ctx).Error("failed to start optional job, skipping", "job", jobID, "err", err)
+			jm.mu.Lock()
+			jm.skippedJobs[jobID] = struct{}{}
+			jm.mu.Unlock()
+			continue
+		}
+		errs = append(errs, fmt.Errorf("job %s: %w", jobID, err))
 	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	jm.startElector(gctx)
+	return nil
+}
 
-	for _, jobID := range orderedJobs.Keys() {
-		j := jm.jobRegistry.Get(jobID)
+// runProducer sets up the given job and runs its producer, until the job is deregistered.
+func (jm *JobManager) runProducer(ctx *sdk.Context, j job.Basic) error {
+	// Every job runs with its own context, so that it can be stopped on its own, along with
+	// anything it started in its setup.
+	jobCtx, cancel := context.WithCancel(ctx)
+	ctx = ctx.WithContext(jobCtx)
 
-		// Run the setup for the job if it has one.
-		if sj, ok := j.(job.HasSetup); ok {
-			if err = sj.Setup(ctx); err != nil {
-				panic(err)
+	// Restore the job's checkpoint, then run the setup for the job if it has one. A block interval
+	// job is run as a block header subscription, whose checkpoint is restored in place of the job's.
+	var (
+		checkpointed         = j
+		blockJob, isBlockJob = j.(job.BlockInterval)
+		blockSub             job.BlockHeaderSub
+	)
+	if isBlockJob {
+		blockSub = job.WrapBlockInterval(blockJob)
+		checkpointed = blockSub
+	}
+	if err := jm.restoreCheckpoint(ctx, checkpointed); err != nil {
+		cancel()
+		return err
+	}
+	if sj, ok := j.(job.HasSetup); ok {
+		if err := sj.Setup(ctx); err != nil {
+			cancel()
+			return fmt.Errorf("setup: %w", err)
+		}
+	}
+
+	// Build the producer task based on the job's type. Use retries if the job uses a
+	// subscription. Payloads are executed on the job's own executor, if it has one.
+	var (
+		task     func()
+		executor = jm.executorFor(j.RegistryKey())
+		policy   = jm.subscriptionPolicy(j)
+	)
+	if wrappedJob := job.WrapJob(j); wrappedJob != nil {
+		task = jm.producerTask(ctx, wrappedJob, executor)
+	} else if subJob, ok := j.(job.Subscribable); ok {
+		task = jm.withRetry(ctx, j, policy,
+			retryableSubscriber(ctx, jm, job.WrapSubscribable(subJob), executor, policy),
+		)
+	} else if anySubJob, ok := j.(job.Subscription[any]); ok { //nolint:govet // todo fix.
+		task = jm.withRetry(ctx, j, policy,
+			retryableSubscriber(ctx, jm, anySubJob, executor, policy),
+		)
+	} else if ethSubJob, ok := j.(job.EthSubscribable); ok { //nolint:govet // todo fix.
+		task = jm.withRetry(ctx, j, policy,
+			retryableSubscriber(ctx, jm, ethSubJob, executor, policy),
+		)
+	} else if blockHeaderJob, ok := j.(job.BlockHeaderSub); ok { //nolint:govet // todo fix.
+		task = jm.withRetry(ctx, j, policy,
+			retryableSubscriber(ctx, jm, blockHeaderJob, executor, policy),
+		)
+	} else if pendingTxJob, ok := j.(job.PendingTxSub); ok { //nolint:govet // todo fix.
+		task = jm.withRetry(ctx, j, policy,
+			retryableSubscriber(ctx, jm, pendingTxJob, executor, policy),
+		)
+	} else if isBlockJob {
+		task = jm.withRetry(ctx, j, policy,
+			retryableSubscriber(ctx, jm, blockSub, executor, policy),
+		)
+	} else if jm.isStage(j.RegistryKey()) {
+		// Stages of a pipeline are only fed by their upstream jobs, so have no producer.
+		task = nil
+	} else {
+		cancel()
+		return fmt.Errorf("unknown job type %s", reflect.TypeOf(j))
+	}
+
+	jm.mu.Lock()
+	done := make(chan struct{})
+	jm.jobCancels[j.RegistryKey()] = cancel
+	jm.jobCtxs[j.RegistryKey()] = ctx
+	jm.jobDones[j.RegistryKey()] = done
+	jm.mu.Unlock()
+	if task != nil {
+		jm.submitProducer(task, done)
+	} else {
+		close(done)
+	}
+	return nil
+}
+
+// failedDependency returns an error if the given job depends on any of the given failed jobs.
+func failedDependency(j job.Basic, failed map[string]struct{}) error {
+	if dj, ok := j.(job.HasDependencies); ok {
+		for _, dep := range dj.Dependencies() {
+			if _, ok = failed[dep]; ok {
+				return fmt.Errorf("dependency %s failed to start", dep)
 			}
 		}
+	}
+	return nil
+}
 
-		// Submit the job to the job producers based on the job's type. Use retries if the job uses
-		// a subscription.
-		if wrappedJob := job.WrapJob(j); wrappedJob != nil {
-			jm.jobProducers.Submit(jm.producerTask(ctx, wrappedJob))
-		} else if subJob, ok := j.(job.Subscribable); ok {
-			jm.jobProducers.Submit(jm.withRetry(jm.retryableSubscriber(ctx, subJob)))
-		} else if ethSubJob, ok := j.(job.EthSubscribable); ok { //nolint:govet // todo fix.
-			jm.jobProducers.Submit(jm.withRetry(jm.retryableEthSubscriber(ctx, ethSubJob)))
-		} else if blockHeaderJob, ok := j.(job.BlockHeaderSub); ok { //nolint:govet // todo fix.
-			jm.jobProducers.Submit(jm.withRetry(jm.retryableHeaderSubscriber(ctx, blockHeaderJob)))
-		} else {
-			panic(fmt.Sprintf("unknown job type %s", reflect.TypeOf(j)))
+// Register registers the given job at runtime. If the jobs are already running, the job is set up
+// and its producer is run right away, adding a producer pool if the producer pools are full. The
+// jobs it depends on, or routes its results to, must already be registered. A singleton job is
+// only run while leading.
+func (jm *JobManager) Register(j job.Basic) error {
+	jm.mu.Lock()
+	if jm.jobRegistry.Has(j.RegistryKey()) {
+		jm.mu.Unlock()
+		return fmt.Errorf("job %s is already registered", j.RegistryKey())
+	}
+	if err := failedDependency(j, jm.skippedJobs); err != nil {
+		jm.mu.Unlock()
+		return fmt.Errorf("job %s: %w", j.RegistryKey(), err)
+	}
+	for _, dep := range jm.dependenciesOf(j) {
+		if !jm.jobRegistry.Has(dep) {
+			jm.mu.Unlock()
+			return fmt.Errorf(
+				"job %s depends on, or routes to, unknown job %s", j.RegistryKey(), dep,
+			)
 		}
 	}
+	if jm.elector != nil {
+		if err := jm.validateSingletonDependencies(j); err != nil {
+			jm.mu.Unlock()
+			return err
+		}
+	}
+	if err := jm.jobRegistry.Register(j); err != nil {
+		jm.mu.Unlock()
+		return err
+	}
+	jm.jobStates.add(j.RegistryKey())
+
+	// If the pools are not started yet, the job is started along with all other jobs.
+	if jm.poolCtx == nil {
+		jm.mu.Unlock()
+		return nil
+	}
+	if err := jm.validateJobExecutor(j); err != nil {
+		jm.removeJob(j.RegistryKey())
+		jm.mu.Unlock()
+		return err
+	}
+	jm.setupJobExecutor(j)
+	ctx := jm.runCtx
+	jm.mu.Unlock()
+
+	// If the producers are not running yet, the job is run along with all other jobs.
+	if ctx == nil {
+		return nil
+	}
+
+	// A singleton job is only run while leading, and otherwise once elected.
+	if isSingleton(j) {
+		jm.singletonMu.Lock()
+		defer jm.singletonMu.Unlock()
+	}
+	if jm.standby(j) {
+		jm.ctxFactory.logger.Info(
+			"registered singleton job, waiting for leadership", "job", j.RegistryKey(),
+		)
+		return nil
+	}
+	if err := jm.runProducer(ctx, j); err != nil {
+		jm.mu.Lock()
+		executor := jm.removeJob(j.RegistryKey())
+		jm.mu.Unlock()
+		if executor != nil {
+			executor.close()
+		}
+		return fmt.Errorf("job %s: %w", j.RegistryKey(), err)
+	}
+
+	jm.ctxFactory.logger.Info("registered job", "job", j.RegistryKey())
+	return nil
+}
+
+// Deregister stops and removes the job with the given key at runtime. Its producer is cancelled
+// and waited for, its own executor (if any) is stopped once its in-flight executions finish, and
+// it is torn down.
+// Jobs that other jobs depend on, or route their results to, cannot be deregistered before them.
+func (jm *JobManager) Deregister(jobKey string) error {
+	jm.mu.Lock()
+	j := jm.jobRegistry.Get(jobKey)
+	if j == nil {
+		jm.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrJobNotFound, jobKey)
+	}
+	for _, other := range jm.jobRegistry.Iterate() {
+		if slices.Contains(jm.dependenciesOf(other), jobKey) {
+			jm.mu.Unlock()
+			return fmt.Errorf(
+				"job %s is a dependency, or downstream, of job %s", jobKey, other.RegistryKey(),
+			)
+		}
+	}
+	// Optional jobs that were skipped on start have no producer running.
+	cancel, running := jm.jobCancels[jobKey]
+	done := jm.jobDones[jobKey]
+	executor := jm.removeJob(jobKey)
+	jm.mu.Unlock()
+
+	if running {
+		cancel()
+		jm.waitProducer(jobKey, done)
+	}
+	if executor != nil {
+		executor.close()
+	}
+	if running {
+		if err := jm.teardown(j); err != nil {
+			return fmt.Errorf("job %s: teardown: %w", jobKey, err)
+		}
+	}
+
+	jm.ctxFactory.logger.Info("deregistered job", "job", jobKey)
+	return nil
+}
+
+// removeJob removes the job with the given key and all of its state, returning its own executor
+// (if any) to be closed. It must be called with the lock held.
+func (jm *JobManager) removeJob(jobKey string) *jobExecutor {
+	executor := jm.jobExecutorsByKey[jobKey]
+	jm.jobRegistry.Remove(jobKey)
+	jm.jobStates.remove(jobKey)
+	delete(jm.jobExecutorsByKey, jobKey)
+	delete(jm.jobCancels, jobKey)
+	delete(jm.jobCtxs, jobKey)
+	delete(jm.jobDones, jobKey)
+	delete(jm.checkpoints, jobKey)
+	delete(jm.skippedJobs, jobKey)
+	return executor
 }
@@ -0,0 +1,183 @@
+package baseapp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/tools/election"
+)
+
+// setLeaderLock sets the lock that the replicas campaign for leadership with, instead of the one
+// from the leader election config.
+func (jm *JobManager) setLeaderLock(lock election.Lock) {
+	jm.leaderLock = lock
+}
+
+// setupElector builds the elector if leader election is enabled, and ensures that no job depends
+// on a singleton job unless it is a singleton job itself. It must be called with the lock held.
+func (jm *JobManager) setupElector() error {
+	if jm.leaderLock == nil {
+		if !jm.leaderCfg.Enabled() {
+			return nil
+		}
+		lock, err := election.NewLock(jm.leaderCfg)
+		if err != nil {
+			return err
+		}
+		jm.leaderLock = lock
+	}
+
+	for _, j := range jm.jobRegistry.Iterate() {
+		if err := jm.validateSingletonDependencies(j); err != nil {
+			return err
+		}
+	}
+	jm.elector = election.NewElector(
+		jm.leaderLock, jm.leaderCfg.RenewInterval, jm.ctxFactory.logger,
+	)
+	return nil
+}
+
+// validateSingletonDependencies ensures that the given job does not depend on a singleton job,
+// which is not run unless leading, unless the given job is a singleton job itself.
+func (jm *JobManager) validateSingletonDependencies(j job.Basic) error {
+	dj, ok := j.(job.HasDependencies)
+	if !ok || isSingleton(j) {
+		return nil
+	}
+	for _, dep := range dj.Dependencies() {
+		if depJob := jm.jobRegistry.Get(dep); depJob != nil && isSingleton(depJob) {
+			return fmt.Errorf(
+				"job %s depends on singleton job %s, so it must be a singleton job too",
+				j.RegistryKey(), dep,
+			)
+		}
+	}
+	return nil
+}
+
+// isSingleton returns whether the given job must only be run by the leader.
+func isSingleton(j job.Basic) bool {
+	sj, ok := j.(job.Singleton)
+	return ok && sj.Singleton()
+}
+
+// standby returns whether the given job is a singleton job that is not run, since this replica is
+// not the leader.
+func (jm *JobManager) standby(j job.Basic) bool {
+	return jm.elector != nil && isSingleton(j) && !jm.elector.IsLeader()
+}
+
+// IsLeader returns whether this replica is the leader, and thus runs the singleton jobs. If leader
+// election is disabled, every replica is the leader.
+func (jm *JobManager) IsLeader() bool {
+	return jm.elector == nil || jm.elector.IsLeader()
+}
+
+// startElector campaigns for leadership in the background, until the given context is cancelled
+// or the job manager is stopped.
+func (jm *JobManager) startElector(ctx context.Context) {
+	if jm.elector == nil {
+		return
+	}
+
+	ctx, jm.electorCancel = context.WithCancel(ctx)
+	jm.electorDone = make(chan struct{})
+	go func() {
+		defer close(jm.electorDone)
+		jm.elector.Run(ctx, jm.runSingletons, jm.stopSingletons)
+	}()
+}
+
+// stopElector stops campaigning for leadership, waiting for the singleton jobs to be stopped and
+// the leader lock to be released.
+func (jm *JobManager) stopElector() {
+	if jm.electorCancel == nil {
+		return
+	}
+	jm.electorCancel()
+	<-jm.electorDone
+	jm.electorCancel = nil
+}
+
+// runSingletons sets up the singleton jobs and runs their producers, once elected. A singleton job
+// that fails to start, or depends on a job that failed, is not run until the next election.
+func (jm *JobManager) runSingletons() {
+	jm.singletonMu.Lock()
+	defer jm.singletonMu.Unlock()
+
+	jm.mu.RLock()
+	ctx := jm.runCtx
+	sorted, err := jm.sortedJobs()
+	failed := maps.Clone(jm.skippedJobs)
+	running := make(map[string]struct{}, len(jm.jobCancels))
+	for jobKey := range jm.jobCancels {
+		running[jobKey] = struct{}{}
+	}
+	jm.mu.RUnlock()
+	if err != nil {
+		jm.ctxFactory.logger.Error("failed to start singleton jobs", "err", err)
+		return
+	}
+
+	// Singleton jobs registered since the election are already running.
+	for _, j := range sorted {
+		if _, ok := running[j.RegistryKey()]; ok || !isSingleton(j) {
+			continue
+		}
+		err = failedDependency(j, failed)
+		if err == nil {
+			err = jm.runProducer(ctx, j)
+		}
+		if err != nil {
+			failed[j.RegistryKey()] = struct{}{}
+			jm.ctxFactory.logger.Error(
+				"failed to start singleton job", "job", j.RegistryKey(), "err", err,
+			)
+		}
+	}
+}
+
+// stopSingletons cancels the producers of the running singleton jobs, waits for them to return so
+// that they submit no more payloads, and tears the jobs down before the jobs they depend on, once
+// leadership is lost.
+func (jm *JobManager) stopSingletons() {
+	jm.singletonMu.Lock()
+	defer jm.singletonMu.Unlock()
+
+	jm.mu.Lock()
+	sorted, err := jm.sortedJobs()
+	if err != nil {
+		jm.mu.Unlock()
+		jm.ctxFactory.logger.Error("failed to stop singleton jobs", "err", err)
+		return
+	}
+	var (
+		running []job.Basic
+		dones   []chan struct{}
+	)
+	for _, j := range sorted {
+		if cancel, ok := jm.jobCancels[j.RegistryKey()]; ok && isSingleton(j) {
+			cancel()
+			running = append(running, j)
+			dones = append(dones, jm.jobDones[j.RegistryKey()])
+			delete(jm.jobCancels, j.RegistryKey())
+			delete(jm.jobCtxs, j.RegistryKey())
+			delete(jm.jobDones, j.RegistryKey())
+		}
+	}
+	jm.mu.Unlock()
+
+	for i, j := range running {
+		jm.waitProducer(j.RegistryKey(), dones[i])
+	}
+	for i := len(running) - 1; i >= 0; i-- {
+		if err = jm.teardown(running[i]); err != nil {
+			jm.ctxFactory.logger.Error(
+				"failed to tear down singleton job", "job", running[i].RegistryKey(), "err", err,
+			)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package baseapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/telemetry"
+)
+
+// routeRetryInterval is how often a result is resubmitted to the full executor of its
+// downstream job, until it is submitted or the route is stopped.
+const routeRetryInterval = 10 * time.Millisecond
+
+// trySubmitter is a worker pool that can submit tasks without blocking.
+type trySubmitter interface {
+	TrySubmit(task func()) bool
+}
+
+// trySubmit submits the task to the given pool without blocking if it supports it, returning
+// whether it was submitted.
+func trySubmit(pool job.WorkerPool, task func()) bool {
+	if ts, ok := pool.(trySubmitter); ok {
+		return ts.TrySubmit(task)
+	}
+	pool.Submit(task)
+	return true
+}
+
+// submitRouted submits the task to the given pool, waiting for the pool to have room for it until
+// either of the given contexts is done, returning whether it was submitted. The pool is never
+// submitted to in a blocking way, since a pool may be stopped while waiting on it.
+func submitRouted(ctx, downstreamCtx context.Context, pool job.WorkerPool, task func()) bool {
+	if trySubmit(pool, task) {
+		return true
+	}
+	ticker := time.NewTicker(routeRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-downstreamCtx.Done():
+			return false
+		case <-ticker.C:
+			if trySubmit(pool, task) {
+				return true
+			}
+		}
+	}
+}
+
+// addPipeline adds the given pipeline, whose routes are validated on start.
+func (jm *JobManager) addPipeline(p *job.Pipeline) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	jm.pipelines = append(jm.pipelines, p)
+}
+
+// isStage returns whether any job routes its results to the job with the given key.
+func (jm *JobManager) isStage(jobKey string) bool {
+	for _, p := range jm.pipelines {
+		if p.IsDownstream(jobKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependenciesOf returns the keys of the jobs that the given job depends on, including the jobs
+// that it routes its results to.
+func (jm *JobManager) dependenciesOf(j job.Basic) []string {
+	deps := job.Dependencies(j)
+	for _, p := range jm.pipelines {
+		deps = append(deps, p.Downstream(j.RegistryKey())...)
+	}
+	return deps
+}
+
+// RouteResult implements jobtypes.Router. The result is executed by every downstream job whose
+// route accepts it, on the downstream job's own executor and with its context. Results for
+// downstream jobs that are not running, e.g. skipped or paused jobs, are dropped.
+//
+// If the downstream job's executor is full, the upstream execution waits for it to have room,
+// which applies backpressure to the upstream job, unless either job is stopped meanwhile. Routes
+// that drop when full instead drop the result right away (and record it in the metrics).
+func (jm *JobManager) RouteResult(ctx context.Context, jobKey string, res any) {
+	for _, p := range jm.pipelines {
+		for _, route := range p.Routes() {
+			if route.From != jobKey || (route.Filter != nil && !route.Filter(ctx, res)) {
+				continue
+			}
+
+			jm.mu.RLock()
+			downstream, downstreamCtx := jm.jobRegistry.Get(route.To), jm.jobCtxs[route.To]
+			jm.mu.RUnlock()
+			if downstream == nil || downstreamCtx == nil {
+				jm.ctxFactory.logger.Debug(
+					"dropping result for downstream job that is not running",
+					"job", jobKey, "downstream", route.To,
+				)
+				continue
+			}
+
+			var (
+				payload  = jobtypes.NewPayload(downstreamCtx, downstream, res)
+				executor = jm.executorFor(route.To)
+			)
+			if route.DropWhenFull {
+				if !trySubmit(executor, payload.Execute) {
+					jm.dropRouted(jobKey, route.To, "dropping result for downstream job "+
+						"whose executor is full")
+				}
+			} else if !submitRouted(ctx, downstreamCtx, executor, payload.Execute) {
+				jm.dropRouted(jobKey, route.To, "dropping result for downstream job "+
+					"that stopped while its executor is full")
+			}
+		}
+	}
+}
+
+// dropRouted logs and records in the metrics that a result routed from the given job to the
+// given downstream job was dropped.
+func (jm *JobManager) dropRouted(jobKey, downstream, msg string) {
+	jm.ctxFactory.logger.Warn(msg, "job", jobKey, "downstream", downstream)
+	jm.ctxFactory.metrics.IncMonotonic(
+		"job.pipeline.dropped", telemetry.ParseLabelPairsToTags(
+			[]string{"job", "downstream"}, []string{jobKey, downstream},
+		)...,
+	)
+}
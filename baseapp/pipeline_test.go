@@ -0,0 +1,140 @@
+package baseapp
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/berachain/offchain-sdk/worker"
+	"github.com/stretchr/testify/require"
+)
+
+// stageJob is a pipeline stage that counts its executions, each of which blocks until released.
+type stageJob struct {
+	key      string
+	executed atomic.Int32
+	release  chan struct{}
+}
+
+func (j *stageJob) RegistryKey() string { return j.key }
+
+func (j *stageJob) Execute(context.Context, any) (any, error) {
+	<-j.release
+	j.executed.Add(1)
+	return nil, nil
+}
+
+// newSaturatedPipeline returns a manager routing the results of the job "upstream" into the
+// given downstream job, whose executor runs a single execution at a time and queues none.
+func newSaturatedPipeline(t *testing.T, downstream *stageJob, p *job.Pipeline) *JobManager {
+	t.Helper()
+	logger := log.NewLogger(io.Discard, "test")
+	metrics := telemetry.NewNoopMetrics()
+	jm := NewManager(
+		[]job.Basic{&stageJob{key: "upstream"}, downstream}, JobManagerConfig{},
+		&contextFactory{logger: logger, metrics: metrics},
+	)
+	jm.addPipeline(p)
+
+	cfg := worker.DefaultPoolConfig()
+	cfg.Name = "pipeline-test-" + t.Name()
+	cfg.PrometheusPrefix = "pipeline_test_" + promSafeName(t.Name())
+	pool := worker.NewPool(context.Background(), logger, cfg)
+	limited := worker.NewLimitedPool(pool, 1, cfg.PrometheusPrefix+"_limited")
+	executor := &jobExecutor{WorkerPool: limited, dedicated: pool, limited: limited}
+	t.Cleanup(executor.close)
+
+	jm.jobExecutorsByKey[downstream.key] = executor
+	jm.jobCtxs[downstream.key] = sdk.NewContext(context.Background(), nil, logger, nil, metrics)
+	return jm
+}
+
+// routeAsync routes the given result from the job "upstream" in the background, returning a
+// channel that is closed once routed.
+func routeAsync(ctx context.Context, jm *JobManager, res any) <-chan struct{} {
+	routed := make(chan struct{})
+	go func() {
+		defer close(routed)
+		jm.RouteResult(ctx, "upstream", res)
+	}()
+	return routed
+}
+
+func TestRouteResultBackpressure(t *testing.T) {
+	downstream := &stageJob{key: "downstream", release: make(chan struct{})}
+	jm := newSaturatedPipeline(t, downstream, job.NewPipeline().Chain("upstream", "downstream"))
+
+	// The first result saturates the downstream executor, so routing the next one waits.
+	jm.RouteResult(context.Background(), "upstream", 1)
+	routed := routeAsync(context.Background(), jm, 2)
+	select {
+	case <-routed:
+		t.Fatal("routed a result into a full executor")
+	case <-time.After(5 * routeRetryInterval):
+	}
+
+	// Once the downstream job catches up, the waiting result is delivered.
+	close(downstream.release)
+	select {
+	case <-routed:
+	case <-time.After(time.Second):
+		t.Fatal("result not routed once the executor has room")
+	}
+	require.Eventually(t, func() bool {
+		return downstream.executed.Load() == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestRouteResultBackpressureCancelled(t *testing.T) {
+	downstream := &stageJob{key: "downstream", release: make(chan struct{})}
+	jm := newSaturatedPipeline(t, downstream, job.NewPipeline().Chain("upstream", "downstream"))
+	jm.RouteResult(context.Background(), "upstream", 1)
+
+	// The upstream job stopping while waiting drops the result.
+	ctx, cancel := context.WithCancel(context.Background())
+	routed := routeAsync(ctx, jm, 2)
+	cancel()
+	select {
+	case <-routed:
+	case <-time.After(time.Second):
+		t.Fatal("routing not stopped along with the upstream job")
+	}
+
+	close(downstream.release)
+	require.Eventually(t, func() bool {
+		return downstream.executed.Load() == 1
+	}, time.Second, time.Millisecond)
+	require.Never(t, func() bool {
+		return downstream.executed.Load() > 1
+	}, 5*routeRetryInterval, routeRetryInterval)
+}
+
+func TestRouteResultDropWhenFull(t *testing.T) {
+	downstream := &stageJob{key: "downstream", release: make(chan struct{})}
+	jm := newSaturatedPipeline(t, downstream, job.NewPipeline().
+		Chain("upstream", "downstream").
+		DropWhenFull("upstream", "downstream"),
+	)
+
+	// Results routed into the full executor are dropped right away.
+	jm.RouteResult(context.Background(), "upstream", 1)
+	select {
+	case <-routeAsync(context.Background(), jm, 2):
+	case <-time.After(time.Second):
+		t.Fatal("waited on a route that drops when full")
+	}
+
+	close(downstream.release)
+	require.Eventually(t, func() bool {
+		return downstream.executed.Load() == 1
+	}, time.Second, time.Millisecond)
+	require.Never(t, func() bool {
+		return downstream.executed.Load() > 1
+	}, 5*routeRetryInterval, routeRetryInterval)
+}
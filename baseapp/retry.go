@@ -1,12 +1,17 @@
 package baseapp
 
 import (
+	"context"
 	"crypto/rand"
+	"errors"
 	"math/big"
 	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 )
 
-// Retry parameters.
+// Default retry parameters, used for anything not set by the app config or the job.
 const (
 	maxBackoff               = 2 * time.Minute
 	backoffStart             = 1 * time.Second
@@ -15,29 +20,78 @@ const (
 	subscriptionStaleTimeout = 1 * time.Hour
 )
 
-// withRetry is a wrapper that retries a task with exponential backoff.
-func (jm *JobManager) withRetry(task func() bool) func() {
+// errSubscriptionStale is returned by a subscription that went stale.
+var errSubscriptionStale = errors.New("subscription went stale")
+
+// defaultSubscriptionPolicy returns the default policy for resubscribing, which retries forever.
+func defaultSubscriptionPolicy() *jobtypes.SubscriptionPolicy {
+	return &jobtypes.SubscriptionPolicy{
+		StaleTimeout:      subscriptionStaleTimeout,
+		RetryBackoff:      backoffStart,
+		MaxRetryBackoff:   maxBackoff,
+		BackoffMultiplier: backoffBase,
+	}
+}
+
+// retryTask is a task that is retried if it returns an error. It also reports whether it made
+// progress before failing, which resets the retries.
+type retryTask func() (progressed bool, err error)
+
+// subscriptionPolicy returns the subscription policy of the given job, with any unset fields
+// filled in from the job manager's config.
+func (jm *JobManager) subscriptionPolicy(j job.Basic) *jobtypes.SubscriptionPolicy {
+	if pj, ok := j.(job.HasSubscriptionPolicy); ok {
+		if policy := pj.SubscriptionPolicy(); policy != nil {
+			return policy.WithDefaults(jm.subscriptionCfg)
+		}
+	}
+	return jm.subscriptionCfg
+}
+
+// withRetry is a wrapper that retries a task of the given job with exponential backoff, according
+// to the given policy. If the policy's retries are exhausted, the job's OnGiveUp hook is called.
+func (jm *JobManager) withRetry(
+	ctx context.Context, j job.Basic, policy *jobtypes.SubscriptionPolicy, task retryTask,
+) func() {
 	return func() {
-		backoff := backoffStart
+		var (
+			backoff = policy.RetryBackoff
+			retries uint
+		)
 
 		for {
-			shouldRetry := task()
-			if !shouldRetry {
+			progressed, err := task()
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+			if progressed {
+				backoff, retries = policy.RetryBackoff, 0
+			}
+
+			if policy.MaxRetries > 0 && retries >= policy.MaxRetries {
+				jm.Logger(ctx).Error(
+					"retries exhausted, giving up", "job", j.RegistryKey(), "retries", retries,
+					"err", err,
+				)
+				if gj, ok := j.(job.HasOnGiveUp); ok {
+					gj.OnGiveUp(ctx, err)
+				}
 				return
 			}
+			retries++
 
 			// Exponential backoff with jitter.
 			jitter, _ := rand.Int(rand.Reader, big.NewInt(jitterRange))
 			if jitter == nil {
 				jitter = new(big.Int)
 			}
-			sleep := backoff + time.Duration(jitter.Int64())*time.Millisecond
-			time.Sleep(sleep)
-
-			backoff *= backoffBase
-			if backoff > maxBackoff {
-				backoff = maxBackoff
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff + time.Duration(jitter.Int64())*time.Millisecond):
 			}
+
+			backoff = policy.NextBackoff(backoff)
 		}
 	}
 }
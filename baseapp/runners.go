@@ -10,9 +10,11 @@ import (
 )
 
 // producerTask returns a execution task for the given HasProducer job.
-func (jm *JobManager) producerTask(ctx context.Context, wrappedJob job.HasProducer) func() {
+func (jm *JobManager) producerTask(
+	ctx context.Context, wrappedJob job.HasProducer, executor job.WorkerPool,
+) func() {
 	return func() {
-		err := wrappedJob.Producer(ctx, jm.jobExecutors)
+		err := wrappedJob.Producer(ctx, executor)
 		if err != nil && !errors.Is(err, context.Canceled) {
 			jm.Logger(ctx).Error(
 				"error in job producer", "job", wrappedJob.RegistryKey(), "err", err,
@@ -21,190 +23,85 @@ func (jm *JobManager) producerTask(ctx context.Context, wrappedJob job.HasProduc
 	}
 }
 
-// retryableSubscriber returns a retryable, execution task for the given Subscribable job.
-func (jm *JobManager) retryableSubscriber(
-	ctx context.Context, subJob job.Subscribable,
-) func() bool {
+// retryableSubscriber returns a retryable, execution task for the given subscription job. It is
+// shared by all subscription jobs, regardless of the type of values they subscribe to.
+func retryableSubscriber[T any](
+	ctx context.Context, jm *JobManager, subJob job.Subscription[T], executor job.WorkerPool,
+	policy *workertypes.SubscriptionPolicy,
+) retryTask {
 	numRetries := 1
 
-	return func() bool {
-		ch := subJob.Subscribe(ctx)
-		jm.Logger(ctx).Info(
-			"(re)subscribed to subscription", "job", subJob.RegistryKey(), "retries", numRetries,
-		)
-
-		// Ensure that the subscription does not drop due to no messages received.
-		staleSubscription := time.After(subscriptionStaleTimeout)
-
-		for {
-			select {
-			case <-ctx.Done():
-				return false // no need to retry
+	// The job is executed as registered, since some jobs are wrapped into a subscription.
+	registeredJob := jm.jobRegistry.Get(subJob.RegistryKey())
 
-			case staleTime := <-staleSubscription:
-				jm.Logger(ctx).Warn(
-					"subscription went stale, reconnecting...",
-					"time", staleTime, "job", subJob.RegistryKey(),
-				)
-				numRetries++
-				return true // should retry again
-
-			case val := <-ch:
-				// Execute the job with the received value.
-				jm.jobExecutors.Submit(workertypes.NewPayload(ctx, subJob, val).Execute)
-
-				// Reset the stale subscription timer since we received a message.
-				staleSubscription = time.After(subscriptionStaleTimeout)
-			}
-		}
-	}
-}
-
-// retryableEthSubscriber returns a retryable, execution task for the given EthSubscribable job.
-//
-// TODO: cleanup the job types interfaces to avoid overlap using generics.
-//
-//nolint:dupl // refer to TODO above.
-func (jm *JobManager) retryableEthSubscriber(
-	ctx context.Context, ethSubJob job.EthSubscribable,
-) func() bool {
-	numRetries := 1
-
-	return func() bool {
-		var (
-			shouldRetry  bool
-			sub, ch, err = ethSubJob.Subscribe(ctx)
-		)
+	return func() (received bool, err error) {
+		sub, ch, err := subJob.Subscribe(ctx)
 
 		// If retrying update the retry count and unsubscribe the previous subscription.
 		defer func() {
 			if sub != nil {
 				sub.Unsubscribe()
 			}
-			if shouldRetry {
+			if err != nil {
 				numRetries++
 			}
 		}()
 
 		// Handle error while subscribing.
-		if shouldRetry = (err != nil); shouldRetry {
+		if err != nil {
 			jm.Logger(ctx).Error(
-				"error subscribing to filter logs, retrying...",
-				"job", ethSubJob.RegistryKey(), "err", err,
+				"error subscribing, retrying...", "job", subJob.RegistryKey(), "err", err,
 			)
-			return shouldRetry
+			return false, err
 		}
 
 		jm.Logger(ctx).Info(
-			"(re)subscribed to eth subscription",
-			"job", ethSubJob.RegistryKey(), "retries", numRetries,
-		)
-
-		// Ensure that the subscription does not drop due to no messages received.
-		staleSubscription := time.After(subscriptionStaleTimeout)
-
-		for {
-			select {
-			case <-ctx.Done():
-				return false // no need to retry
-
-			case err = <-sub.Err():
-				jm.Logger(ctx).Error(
-					"error in subscription, retrying...",
-					"job", ethSubJob.RegistryKey(), "err", err,
-				)
-				shouldRetry = err != nil
-				return shouldRetry
-
-			case staleTime := <-staleSubscription:
-				jm.Logger(ctx).Warn(
-					"subscription went stale, reconnecting...",
-					"time", staleTime, "job", ethSubJob.RegistryKey(),
-				)
-				shouldRetry = true
-				return shouldRetry
-
-			case val := <-ch:
-				// Execute the job with the received value.
-				jm.jobExecutors.Submit(workertypes.NewPayload(ctx, ethSubJob, val).Execute)
-
-				// Reset the stale subscription timer since we received a message.
-				staleSubscription = time.After(subscriptionStaleTimeout)
-			}
-		}
-	}
-}
-
-// retryableEthSubscriber returns a retryable, execution task for the given BlockHeaderSub job.
-//
-// TODO: cleanup the job types interfaces to avoid overlap using generics.
-//
-//nolint:dupl // refer to TODO above.
-func (jm *JobManager) retryableHeaderSubscriber(
-	ctx context.Context, blockHeaderJob job.BlockHeaderSub,
-) func() bool {
-	numRetries := 1
-
-	return func() bool {
-		var (
-			shouldRetry  bool
-			sub, ch, err = blockHeaderJob.Subscribe(ctx)
+			"(re)subscribed to subscription", "job", subJob.RegistryKey(), "retries", numRetries,
 		)
 
-		// If retrying update the retry count and unsubscribe the previous subscription.
-		defer func() {
-			if sub != nil {
-				sub.Unsubscribe()
-			}
-			if shouldRetry {
-				numRetries++
-			}
-		}()
-
-		// Handle error while subscribing.
-		if shouldRetry = (err != nil); shouldRetry {
-			jm.Logger(ctx).Error(
-				"error subscribing to block headers, retrying...",
-				"job", blockHeaderJob.RegistryKey(), "err", err,
-			)
-			return shouldRetry
+		// Sources that never fail have no subscription, whose nil error channel never fires.
+		var subErrs <-chan error
+		if sub != nil {
+			subErrs = sub.Err()
 		}
 
-		jm.Logger(ctx).Info(
-			"(re)subscribed to eth subscription",
-			"job", blockHeaderJob.RegistryKey(), "retries", numRetries,
-		)
-
 		// Ensure that the subscription does not drop due to no messages received.
-		staleSubscription := time.After(subscriptionStaleTimeout)
+		staleSubscription := time.After(policy.StaleTimeout)
 
 		for {
 			select {
 			case <-ctx.Done():
-				return false // no need to retry
+				return received, nil // no need to retry
 
-			case err = <-sub.Err():
+			case err = <-subErrs:
 				jm.Logger(ctx).Error(
-					"error in subscription, retrying...",
-					"job", blockHeaderJob.RegistryKey(), "err", err,
+					"error in subscription, retrying...", "job", subJob.RegistryKey(), "err", err,
 				)
-				shouldRetry = err != nil
-				return shouldRetry
+				return received, err
 
 			case staleTime := <-staleSubscription:
 				jm.Logger(ctx).Warn(
 					"subscription went stale, reconnecting...",
-					"time", staleTime, "job", blockHeaderJob.RegistryKey(),
+					"time", staleTime, "job", subJob.RegistryKey(),
 				)
-				shouldRetry = true
-				return shouldRetry
+				err = errSubscriptionStale
+				return received, err
+
+			case val, ok := <-ch:
+				if !ok {
+					jm.Logger(ctx).Error(
+						"subscription closed, retrying...", "job", subJob.RegistryKey(),
+					)
+					err = job.ErrSubscriptionClosed
+					return received, err
+				}
 
-			case val := <-ch:
 				// Execute the job with the received value.
-				jm.jobExecutors.Submit(workertypes.NewPayload(ctx, blockHeaderJob, val).Execute)
+				jm.submitInput(ctx, executor, registeredJob, subJob, val)
+				received = true
 
 				// Reset the stale subscription timer since we received a message.
-				staleSubscription = time.After(subscriptionStaleTimeout)
+				staleSubscription = time.After(policy.StaleTimeout)
 			}
 		}
 	}
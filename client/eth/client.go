@@ -30,6 +30,9 @@ type Reader interface {
 	) ([]*ethcoretypes.Receipt, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethcoretypes.Receipt, error)
 	SubscribeNewHead(ctx context.Context) (chan *ethcoretypes.Header, ethereum.Subscription, error)
+	SubscribePendingTransactions(
+		ctx context.Context,
+	) (chan common.Hash, ethereum.Subscription, error)
 	BlockNumber(ctx context.Context) (uint64, error)
 	ChainID(ctx context.Context) (*big.Int, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
@@ -180,6 +183,17 @@ func (c *ExtendedEthClient) SubscribeNewHead(
 	return ch, sub, err
 }
 
+// SubscribePendingTransactions subscribes to the hashes of new pending transactions.
+func (c *ExtendedEthClient) SubscribePendingTransactions(
+	ctx context.Context,
+) (chan common.Hash, ethereum.Subscription, error) {
+	ch := make(chan common.Hash)
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.rpcTimeout)
+	sub, err := c.Client.Client().EthSubscribe(ctxWithTimeout, ch, "newPendingTransactions")
+	cancel()
+	return ch, sub, err
+}
+
 func (c *ExtendedEthClient) SubscribeFilterLogs(
 	ctx context.Context,
 	q ethereum.FilterQuery, ch chan<- ethcoretypes.Log) (ethereum.Subscription, error) {
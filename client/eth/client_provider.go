@@ -123,6 +123,22 @@ func (c *ChainProviderImpl) SubscribeNewHead(
 	return nil, nil, ErrClientNotFound
 }
 
+// SubscribePendingTransactions subscribes to the hashes of new pending transactions.
+func (c *ChainProviderImpl) SubscribePendingTransactions(
+	ctx context.Context,
+) (chan common.Hash, ethereum.Subscription, error) {
+	if client, ok := c.GetWS(); ok {
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.rpcTimeout)
+		defer cancel()
+
+		var err error
+		defer c.recordRPCMethod(client.ClientID(), "eth_subscribe", time.Now(), err)
+		hashes, sub, err := client.SubscribePendingTransactions(ctxWithTimeout)
+		return hashes, sub, err
+	}
+	return nil, nil, ErrClientNotFound
+}
+
 // BlockNumber returns the current block number.
 func (c *ChainProviderImpl) BlockNumber(ctx context.Context) (uint64, error) {
 	if client, ok := c.GetHTTP(); ok {
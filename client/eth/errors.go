@@ -1,8 +1,41 @@
 package eth
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	ErrAlreadyDial = errors.New("client is already dialed, please Close() before dialing again")
 	ErrClosed      = errors.New("client is already closed, please Dial() before closing again")
 )
+
+// rangeLimitMessages are the messages by which the common RPC providers reject log queries that
+// span too many blocks or return too many logs. Rate limits, which share their error code on some
+// providers, are not range limits.
+var rangeLimitMessages = []string{
+	"block range",
+	"range is too large",
+	"range too large",
+	"exceed maximum block range",
+	"exceeds max block range",
+	"more than 10000 results",
+	"too many results",
+	"response size exceeded",
+	"response size should not greater than",
+}
+
+// IsRangeLimitError returns whether the given error is an RPC's rejection of a log query for the
+// number of blocks it spans or logs it returns, so that it may succeed with a smaller range.
+func IsRangeLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, limitMsg := range rangeLimitMessages {
+		if strings.Contains(msg, limitMsg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package eth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsRangeLimitError tests that only the rejections of log queries for their range or result
+// size are range limit errors.
+func TestIsRangeLimitError(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{context.DeadlineExceeded, false},
+		{errors.New("connection refused"), false},
+		{errors.New("project ID request rate exceeded"), false},
+		{errors.New("query returned more than 10000 results"), true},
+		{errors.New("Block range is too large"), true},
+		{errors.New("eth_getLogs: exceed maximum block range: 5000"), true},
+		{errors.New("Log response size exceeded."), true},
+	} {
+		require.Equal(t, tc.expected, eth.IsRangeLimitError(tc.err), "%v", tc.err)
+	}
+}
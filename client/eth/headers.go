@@ -0,0 +1,95 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// HeaderCursor follows new block headers from the height of the last header delivered, so that
+// the headers of the blocks missed between two heads, e.g. while resubscribing, are fetched by
+// number and no header is delivered twice. The zero value starts from the first head received.
+type HeaderCursor struct {
+	mu      sync.Mutex
+	started bool
+	last    uint64
+}
+
+// Restore resumes the cursor from the given height, i.e. the next header delivered is the one
+// after it.
+func (c *HeaderCursor) Restore(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last, c.started = height, true
+}
+
+// Follow wraps the given subscription to new heads into a subscription that delivers, for each
+// head, the headers since the last delivered height up to the head, of the heights that accept
+// (if any) accepts. Heights at or below the last delivered height, e.g. on reorgs, are skipped.
+// The returned subscription ends with the head subscription, or on the first failed RPC call.
+func (c *HeaderCursor) Follow(
+	ctx context.Context, reader Reader, heads <-chan *types.Header,
+	headSub ethereum.Subscription, accept func(height uint64) bool,
+) (ethereum.Subscription, chan *types.Header) {
+	ch := make(chan *types.Header)
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		defer headSub.Unsubscribe()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err := <-headSub.Err():
+				return err
+			case head := <-heads:
+				if err := c.deliver(ctx, reader, head, ch, quit, accept); err != nil {
+					return err
+				}
+			}
+		}
+	})
+	return sub, ch
+}
+
+// deliver delivers the accepted headers from the last delivered height up to the given head. The
+// cursor is only locked to read and advance the height, not while fetching or sending headers.
+func (c *HeaderCursor) deliver(
+	ctx context.Context, reader Reader, head *types.Header,
+	ch chan<- *types.Header, quit <-chan struct{}, accept func(height uint64) bool,
+) error {
+	height, next := head.Number.Uint64(), head.Number.Uint64()
+	c.mu.Lock()
+	if c.started {
+		next = c.last + 1
+	}
+	c.mu.Unlock()
+
+	for ; next <= height; next++ {
+		if accept == nil || accept(next) {
+			header := head
+			if next != height {
+				var err error
+				if header, err = reader.HeaderByNumber(ctx, new(big.Int).SetUint64(next)); err != nil {
+					return err
+				}
+			}
+			select {
+			case <-quit:
+				return nil
+			case ch <- header:
+			}
+		}
+		c.advance(next)
+	}
+	return nil
+}
+
+// advance records the given height as delivered.
+func (c *HeaderCursor) advance(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last, c.started = height, true
+}
@@ -0,0 +1,64 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHeaderCursorFollow tests that following new heads delivers the accepted headers since the
+// last delivered height, fetching the missed ones by number, and skips the heights delivered.
+func TestHeaderCursorFollow(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("HeaderByNumber", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{Number: number}, nil
+		},
+	)
+
+	var cursor eth.HeaderCursor
+	cursor.Restore(10)
+	heads := make(chan *types.Header)
+	headSub := event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+	sub, ch := cursor.Follow(context.Background(), client, heads, headSub, func(height uint64) bool {
+		return height != 12
+	})
+	defer sub.Unsubscribe()
+
+	// Height 12 is not accepted, and heights 12 and 13 are not delivered again on a reorg.
+	for _, step := range []struct {
+		head     int64
+		expected []int64
+	}{
+		{head: 13, expected: []int64{11, 13}},
+		{head: 12},
+		{head: 13},
+		{head: 14, expected: []int64{14}},
+	} {
+		heads <- &types.Header{Number: big.NewInt(step.head)}
+		for _, expected := range step.expected {
+			select {
+			case header := <-ch:
+				require.Equal(t, expected, header.Number.Int64())
+			case err := <-sub.Err():
+				t.Fatalf("unexpected subscription error: %v", err)
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for block %d", expected)
+			}
+		}
+	}
+
+	// Only the missed height that is accepted is fetched by number.
+	client.AssertNumberOfCalls(t, "HeaderByNumber", 1)
+}
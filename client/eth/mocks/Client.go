@@ -593,6 +593,45 @@ func (_m *Client) SubscribeNewHead(ctx context.Context) (chan *types.Header, eth
 	return r0, r1, r2
 }
 
+// SubscribePendingTransactions provides a mock function with given fields: ctx
+func (_m *Client) SubscribePendingTransactions(ctx context.Context) (chan common.Hash, ethereum.Subscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribePendingTransactions")
+	}
+
+	var r0 chan common.Hash
+	var r1 ethereum.Subscription
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (chan common.Hash, ethereum.Subscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) chan common.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(chan common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) ethereum.Subscription); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(ethereum.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SuggestGasPrice provides a mock function with given fields: ctx
 func (_m *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	ret := _m.Called(ctx)
@@ -0,0 +1,252 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DefaultPollInterval is the interval at which subscriptions poll the chain over HTTP when no
+// websocket connection is available.
+const DefaultPollInterval = time.Second
+
+// PollNewHeads polls the chain over HTTP for new block headers at the given interval, as a
+// fallback for SubscribeNewHead when no websocket connection is available. Like SubscribeNewHead,
+// the returned subscription delivers the headers of the blocks after the current head, in order.
+// The subscription ends with an error on the first failed RPC call.
+func PollNewHeads(
+	ctx context.Context, reader Reader, interval time.Duration,
+) (chan *types.Header, ethereum.Subscription) {
+	ch := make(chan *types.Header)
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		last, err := reader.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			head, err := reader.BlockNumber(ctx)
+			if err != nil {
+				return err
+			}
+
+			// Deliver the headers of all blocks since the last poll.
+			for number := last + 1; number <= head; number++ {
+				header, err := reader.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+				if err != nil {
+					return err
+				}
+				select {
+				case <-quit:
+					return nil
+				case ch <- header:
+					last = number
+				}
+			}
+		}
+	})
+	return ch, sub
+}
+
+const (
+	// maxPollLogsRange is the maximum number of blocks whose logs are queried at once when
+	// polling.
+	maxPollLogsRange = 1000
+	// pollReorgWindow is the number of polled blocks that are tracked for reorgs when polling.
+	pollReorgWindow = 128
+)
+
+// PollFilterLogs polls the chain over HTTP for new logs that satisfy the given filter query at the
+// given interval, as a fallback for SubscribeFilterLogs when no websocket connection is available.
+// Like SubscribeFilterLogs, the subscription delivers the logs of the blocks after the current
+// head, in order, querying them in windows of blocks. If any of the last polled blocks drops out
+// of the canonical chain, the logs delivered from it are delivered again as removed, most recent
+// first, and the logs of the blocks that replace it are delivered next. The subscription ends with
+// an error on the first failed RPC call.
+func PollFilterLogs(
+	ctx context.Context, reader Reader, q ethereum.FilterQuery, ch chan<- types.Log,
+	interval time.Duration,
+) (ethereum.Subscription, error) {
+	// Get the current head before returning, so that the logs of the blocks after it are delivered.
+	last, err := reader.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &logPoller{
+		reader: reader,
+		query:  q,
+		last:   last,
+		hashes: make(map[uint64]common.Hash),
+		logs:   make(map[uint64][]types.Log),
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		deliver := func(log types.Log) bool {
+			select {
+			case <-quit:
+				return false
+			case ch <- log:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			if err = p.poll(ctx, deliver); err != nil {
+				return err
+			}
+		}
+	}), nil
+}
+
+// logPoller polls for the logs of a filter query, keeping track of the recently polled blocks and
+// the logs delivered from them to detect reorgs.
+type logPoller struct {
+	reader Reader
+	query  ethereum.FilterQuery
+	// last is the last polled block.
+	last uint64
+	// hashes are the hashes of the recently polled blocks that are known, by height.
+	hashes map[uint64]common.Hash
+	// logs are the logs delivered from the recently polled blocks, by height.
+	logs map[uint64][]types.Log
+}
+
+// poll delivers the removed logs of the polled blocks that dropped out of the canonical chain, if
+// any, then the logs of all blocks since the last poll, a window of blocks at a time. It stops
+// early if delivering a log fails, e.g. the subscription quit.
+func (p *logPoller) poll(ctx context.Context, deliver func(types.Log) bool) error {
+	removed, err := p.rewind(ctx)
+	if err != nil {
+		return err
+	}
+	for _, log := range removed {
+		if !deliver(log) {
+			return nil
+		}
+	}
+
+	head, err := p.reader.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	for p.last < head {
+		from, to := p.last+1, min(p.last+maxPollLogsRange, head)
+		header, err := p.reader.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return err
+		}
+		q := p.query
+		q.FromBlock, q.ToBlock = new(big.Int).SetUint64(from), new(big.Int).SetUint64(to)
+		logs, err := p.reader.FilterLogs(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		// A log from another block than the header at the same height is caught by the next poll.
+		p.hashes[to] = header.Hash()
+		for _, log := range logs {
+			if !deliver(log) {
+				return nil
+			}
+			p.hashes[log.BlockNumber] = log.BlockHash
+			p.logs[log.BlockNumber] = append(p.logs[log.BlockNumber], log)
+		}
+		p.last = to
+	}
+	p.prune()
+	return nil
+}
+
+// rewind checks whether the last polled block is still canonical. If not, it rewinds the poller
+// to the most recent tracked block that is, returning the logs delivered from the blocks after it
+// as removed, most recent first. If no tracked block is canonical anymore, it rewinds to the block
+// before the lowest tracked one.
+func (p *logPoller) rewind(ctx context.Context) ([]types.Log, error) {
+	hash, tracked := p.hashes[p.last]
+	if !tracked {
+		return nil, nil
+	}
+	if canonical, err := p.isCanonical(ctx, p.last, hash); err != nil || canonical {
+		return nil, err
+	}
+
+	heights := slices.Sorted(maps.Keys(p.hashes))
+	ancestor := max(heights[0], 1) - 1
+	for i := len(heights) - 2; i >= 0; i-- {
+		canonical, err := p.isCanonical(ctx, heights[i], p.hashes[heights[i]])
+		if err != nil {
+			return nil, err
+		}
+		if canonical {
+			ancestor = heights[i]
+			break
+		}
+	}
+
+	var removed []types.Log
+	for i := len(heights) - 1; i >= 0 && heights[i] > ancestor; i-- {
+		logs := p.logs[heights[i]]
+		for j := len(logs) - 1; j >= 0; j-- {
+			logs[j].Removed = true
+			removed = append(removed, logs[j])
+		}
+		delete(p.hashes, heights[i])
+		delete(p.logs, heights[i])
+	}
+	p.last = ancestor
+	return removed, nil
+}
+
+// isCanonical returns whether the block with the given hash is the canonical one at its height.
+func (p *logPoller) isCanonical(
+	ctx context.Context, height uint64, hash common.Hash,
+) (bool, error) {
+	header, err := p.reader.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil // the chain is shorter now
+	} else if err != nil {
+		return false, err
+	}
+	return header != nil && header.Hash() == hash, nil
+}
+
+// prune stops tracking the blocks below the reorg window.
+func (p *logPoller) prune() {
+	if p.last < pollReorgWindow {
+		return
+	}
+	floor := p.last - pollReorgWindow
+	for height := range p.hashes {
+		if height < floor {
+			delete(p.hashes, height)
+			delete(p.logs, height)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPollNewHeads tests that polling delivers the headers of all new blocks in order.
+func TestPollNewHeads(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("BlockNumber", mock.Anything).Return(uint64(10), nil).Once()
+	client.On("BlockNumber", mock.Anything).Return(uint64(12), nil)
+	client.On("HeaderByNumber", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{Number: number}, nil
+		},
+	)
+
+	ch, sub := eth.PollNewHeads(context.Background(), client, time.Millisecond)
+	defer sub.Unsubscribe()
+
+	for _, expected := range []int64{11, 12} {
+		select {
+		case header := <-ch:
+			require.Equal(t, expected, header.Number.Int64())
+		case err := <-sub.Err():
+			t.Fatalf("unexpected subscription error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for block %d", expected)
+		}
+	}
+}
+
+// TestPollFilterLogs tests that polling delivers the logs of all new blocks in order, querying
+// them in windows of blocks.
+func TestPollFilterLogs(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("BlockNumber", mock.Anything).Return(uint64(10), nil).Once()
+	client.On("BlockNumber", mock.Anything).Return(uint64(1500), nil)
+	client.On("FilterLogs", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+			return []types.Log{
+				{BlockNumber: q.FromBlock.Uint64()}, {BlockNumber: q.ToBlock.Uint64()},
+			}, nil
+		},
+	)
+	client.On("HeaderByNumber", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{Number: number}, nil
+		},
+	)
+
+	ch := make(chan types.Log)
+	sub, err := eth.PollFilterLogs(
+		context.Background(), client, ethereum.FilterQuery{}, ch, time.Millisecond,
+	)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for _, expected := range []uint64{11, 1010, 1011, 1500} {
+		select {
+		case log := <-ch:
+			require.Equal(t, expected, log.BlockNumber)
+		case err = <-sub.Err():
+			t.Fatalf("unexpected subscription error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for a log of block %d", expected)
+		}
+	}
+}
+
+// TestPollFilterLogsReorg tests that polling delivers the logs of the blocks that dropped out of
+// the canonical chain again as removed, then the logs of the blocks that replace them.
+func TestPollFilterLogsReorg(t *testing.T) {
+	// The chain forks at block 11, replacing blocks 12 and 13 once the first poll is done.
+	var (
+		mu   sync.Mutex
+		fork byte
+	)
+	headerAt := func(number uint64) *types.Header {
+		mu.Lock()
+		defer mu.Unlock()
+		header := &types.Header{Number: new(big.Int).SetUint64(number)}
+		if number > 11 {
+			header.Extra = []byte{fork}
+		}
+		return header
+	}
+	client := new(mocks.Client)
+	client.On("BlockNumber", mock.Anything).Return(uint64(10), nil).Once()
+	client.On("BlockNumber", mock.Anything).Return(uint64(13), nil)
+	client.On("HeaderByNumber", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, number *big.Int) (*types.Header, error) {
+			return headerAt(number.Uint64()), nil
+		},
+	)
+	client.On("FilterLogs", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+			var logs []types.Log
+			for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64(); n++ {
+				logs = append(logs, types.Log{BlockNumber: n, BlockHash: headerAt(n).Hash()})
+			}
+			return logs, nil
+		},
+	)
+
+	ch := make(chan types.Log)
+	sub, err := eth.PollFilterLogs(
+		context.Background(), client, ethereum.FilterQuery{}, ch, time.Millisecond,
+	)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receive := func() types.Log {
+		select {
+		case log := <-ch:
+			return log
+		case err = <-sub.Err():
+			t.Fatalf("unexpected subscription error: %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a log")
+		}
+		return types.Log{}
+	}
+	for _, expected := range []uint64{11, 12, 13} {
+		require.Equal(t, expected, receive().BlockNumber)
+	}
+
+	mu.Lock()
+	fork = 1
+	mu.Unlock()
+	for _, expected := range []struct {
+		number  uint64
+		removed bool
+	}{{13, true}, {12, true}, {12, false}, {13, false}} {
+		log := receive()
+		require.Equal(t, expected.number, log.BlockNumber)
+		require.Equal(t, expected.removed, log.Removed)
+		require.Equal(t, expected.removed, log.BlockHash != headerAt(log.BlockNumber).Hash())
+	}
+}
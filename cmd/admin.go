@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/berachain/offchain-sdk/baseapp"
+	"github.com/berachain/offchain-sdk/cmd/flags"
+	"github.com/berachain/offchain-sdk/config"
+	"github.com/berachain/offchain-sdk/config/toml"
+	"github.com/spf13/cobra"
+)
+
+// adminRequestTimeout bounds the requests to the admin API.
+const adminRequestTimeout = 10 * time.Second
+
+// AdminCmd returns the command to interact with the jobs of a running service, through the admin
+// API served on its HTTP server.
+func AdminCmd(defaultAppHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Interact with the jobs of a running service",
+		Long: `Interact with the jobs of a running service, through the admin API served on its ` +
+			`HTTP server (requires Server.HTTP.Admin to be enabled). Requests carry the admin ` +
+			`token given by --token, or else the Server.HTTP.AdminToken of the config`,
+	}
+
+	cmd.AddCommand(
+		adminRequestCmd(defaultAppHome, "jobs", "List the statuses of all jobs", http.MethodGet, ""),
+		adminRequestCmd(defaultAppHome, "status", "Show the status of a job", http.MethodGet, ""),
+		adminRequestCmd(defaultAppHome, "pause", "Pause a job", http.MethodPost, "pause"),
+		adminRequestCmd(defaultAppHome, "resume", "Resume a paused job", http.MethodPost, "resume"),
+		adminRequestCmd(defaultAppHome, "run", "Run a job right away", http.MethodPost, "run"),
+	)
+
+	cmd.PersistentFlags().String(flags.ConfigPath, flags.DefaultConfigPath, "The config directory")
+	cmd.PersistentFlags().String(
+		flags.AdminAddr, "", "The address of the service, e.g. http://localhost:8080, "+
+			"instead of the HTTP server in the config",
+	)
+	cmd.PersistentFlags().String(
+		flags.AdminToken, "", "The bearer token of the admin API, instead of the "+
+			"Server.HTTP.AdminToken in the config",
+	)
+	return cmd
+}
+
+// adminRequestCmd returns the command that sends a request to the admin API. All commands but
+// "jobs" take the job's registry key as their argument, and all but "jobs" and "status" perform
+// the given action on the job.
+func adminRequestCmd(defaultAppHome, use, short, method, action string) *cobra.Command {
+	args := cobra.ExactArgs(1)
+	if use == "jobs" {
+		args = cobra.NoArgs
+	} else {
+		use += " [job]"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, token, err := adminTarget(cmd, defaultAppHome)
+			if err != nil {
+				return err
+			}
+
+			path := "jobs"
+			if len(args) > 0 {
+				path += "/" + url.PathEscape(args[0])
+			}
+			if action != "" {
+				path += "/" + action
+			}
+
+			req, err := http.NewRequestWithContext(
+				cmd.Context(), method, addr+baseapp.AdminPath+path, nil,
+			)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := (&http.Client{Timeout: adminRequestTimeout}).Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+			}
+			_, err = cmd.OutOrStdout().Write(body)
+			return err
+		},
+	}
+}
+
+// adminTarget returns the address of the service's HTTP server and the bearer token of its admin
+// API, either as given by flag or as configured in the config file.
+func adminTarget(cmd *cobra.Command, defaultAppHome string) (string, string, error) {
+	addr, err := cmd.Flags().GetString(flags.AdminAddr)
+	if err != nil {
+		return "", "", err
+	}
+	token, err := cmd.Flags().GetString(flags.AdminToken)
+	if err != nil {
+		return "", "", err
+	}
+	if addr != "" && token != "" {
+		return strings.TrimSuffix(addr, "/"), token, nil
+	}
+
+	configPath, err := cmd.Flags().GetString(flags.ConfigPath)
+	if err != nil {
+		return "", "", err
+	}
+	if configPath == "" {
+		configPath = defaultAppHome
+	}
+
+	var cfg config.Config[struct{}]
+	if err = toml.LoadConfig[config.Config[struct{}]](configPath, &cfg, false, ""); err != nil {
+		return "", "", err
+	}
+	if token == "" {
+		if token = cfg.Server.HTTP.AdminToken; token == "" {
+			return "", "", errors.New(
+				"no admin token given by flag nor set as Server.HTTP.AdminToken in the config",
+			)
+		}
+	}
+	if addr != "" {
+		return strings.TrimSuffix(addr, "/"), token, nil
+	}
+	if !cfg.Server.HTTP.Enabled() {
+		return "", "", errors.New("the HTTP server is not enabled in the config")
+	}
+
+	host := cfg.Server.HTTP.Host
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("http://%s:%d", host, cfg.Server.HTTP.Port), token, nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/baseapp"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes a config file with the given admin token and returns its path.
+func writeConfig(t *testing.T, token string) string {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg := "[Server.HTTP]\nPort = 8080\nAdmin = true\nAdminToken = \"" + token + "\"\n"
+	require.NoError(t, os.WriteFile(path, []byte(cfg), 0o600))
+	return path
+}
+
+func TestAdminCmdToken(t *testing.T) {
+	jm := baseapp.NewManager(nil, baseapp.JobManagerConfig{}, nil)
+	srv := httptest.NewServer(jm.AdminHandler("secret"))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name  string
+		flags []string
+		token string // of the config
+		err   string
+	}{
+		{name: "token flag", flags: []string{"--token", "secret"}},
+		{name: "config token", token: "secret"},
+		{name: "token flag over config", flags: []string{"--token", "secret"}, token: "other"},
+		{name: "wrong token", flags: []string{"--token", "other"}, err: "401 Unauthorized"},
+		{name: "no token", err: "no admin token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := AdminCmd(t.TempDir())
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetArgs(append([]string{
+				"jobs", "--addr", srv.URL, "--config-path", writeConfig(t, tc.token),
+			}, tc.flags...))
+
+			err := cmd.Execute()
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, "[]", out.String())
+		})
+	}
+}
@@ -5,4 +5,6 @@ const (
 	DefaultConfigPath = "config.toml"
 	EnvOverride       = "env-override"
 	EnvOverridePrefix = "prefix"
+	AdminAddr         = "addr"
+	AdminToken        = "token"
 )
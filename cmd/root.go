@@ -17,6 +17,7 @@ func BuildBasicRootCmd[C any](app coreapp.App[C]) *cobra.Command {
 
 	rootCmd.AddCommand(
 		StartCmd(app, os.Getenv("HOME")),
+		AdminCmd(os.Getenv("HOME")),
 	)
 
 	return rootCmd
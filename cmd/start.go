@@ -65,6 +65,7 @@ func StartCmdWithOptions[C any](
 			}
 
 			ab := baseapp.NewAppBuilder(app.Name())
+			ab.RegisterJobManagerConfig(cfg.JobManager)
 
 			logger := log.NewWithCfg(cmd.OutOrStdout(), app.Name(), cfg.Log)
 			// // Maybe move this to BuildApp?
@@ -92,30 +93,35 @@ func StartCmdWithOptions[C any](
 			if cfg.Server.HTTP.Enabled() {
 				svr := server.New(&cfg.Server, logger)
 				ab.RegisterHTTPServer(svr)
+				if cfg.Server.HTTP.Admin {
+					if err = ab.RegisterAdminHandler(cfg.Server.HTTP.AdminToken); err != nil {
+						return err
+					}
+				}
 			}
 
 			// Build the application, then start it.
 			app.Setup(ab, cfg.App, logger)
 			if err = app.Start(ctx); err != nil {
-				return err
+				// Stop and tear down the jobs that were already set up.
+				stop()
+				return errors.Join(err, app.Stop())
 			}
 
 			// Wait for the context to be done.
 			<-ctx.Done()
 			err = ctx.Err()
 
-			defer func() {
-				logger.Info("received interrupt signal, exiting gracefully...", ctx.Done())
-				app.Stop()
-				stop()
-			}()
+			logger.Info("received interrupt signal, exiting gracefully...")
+			defer stop()
+			stopErr := app.Stop()
 
 			// TODO: should we return error here based on ctx.Err()?
 			if errors.Is(err, context.Canceled) {
-				return nil
+				return stopErr
 			}
 
-			return err
+			return errors.Join(err, stopErr)
 		},
 	}
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/berachain/offchain-sdk/baseapp"
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
@@ -23,4 +24,7 @@ type Config[C any] struct {
 
 	// Log Config
 	Log log.Config
+
+	// JobManager Config
+	JobManager baseapp.JobManagerConfig
 }
@@ -10,5 +10,5 @@ type App[C any] interface {
 	Name() string
 	Setup(ab Builder, config C, logger log.Logger)
 	Start(context.Context) error
-	Stop()
+	Stop() error
 }
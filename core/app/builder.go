@@ -6,6 +6,7 @@ import (
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
 	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/tools/election"
 
 	"github.com/ethereum/go-ethereum/ethdb"
 )
@@ -15,9 +16,13 @@ type Builder interface {
 	AppName() string
 	BuildApp(log.Logger) *baseapp.BaseApp
 	RegisterJob(job.Basic)
+	RegisterPipeline(p *job.Pipeline)
+	RegisterJobManagerConfig(cfg baseapp.JobManagerConfig)
 	RegisterMetrics(cfg *telemetry.Config) error
 	RegisterDB(db ethdb.KeyValueStore)
 	RegisterHTTPHandler(handler *server.Handler) error
 	RegisterMiddleware(m server.Middleware) error
 	RegisterPrometheusTelemetry() error
+	RegisterAdminHandler(token string) error
+	RegisterLeaderLock(lock election.Lock)
 }
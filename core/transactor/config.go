@@ -1,11 +1,22 @@
 package transactor
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/berachain/offchain-sdk/types/queue/sqs"
 )
 
+const (
+	// Tx requests whose tx would exceed its max gas fee cap are failed.
+	MaxGasFeeReject = "reject"
+	// Tx requests whose tx would exceed its max gas fee cap are requeued.
+	MaxGasFeeQueue = "queue"
+)
+
+// defaultStatusRetention is how long the status of a done tx request is kept by default.
+const defaultStatusRetention = 24 * time.Hour
+
 type Config struct {
 	// How large an individual batched tx will be (uses multicall contract if > 1).
 	TxBatchSize int
@@ -37,4 +48,35 @@ type Config struct {
 	// If true, the queue (SQS generates its own) message ID will be used for tracking messages,
 	// rather than the optional, user-provided message ID.
 	UseQueueMessageID bool
+
+	// (Optional) Where the lifecycle of every tx request is persisted, so that it is reconciled
+	// against the chain after a restart: "db" for the app's DB, or "sql" for a SQL database. If
+	// left empty, tx requests are only tracked in memory. Requests without a message ID are never
+	// persisted.
+	StateStore string
+	// The name of the registered `database/sql` driver and the DSN, for the "sql" state store.
+	StateStoreSQLDriver string
+	StateStoreSQLDSN    string
+	// How requests are dispatched across the signers, if multiple: "roundRobin" (default),
+	// "leastInFlight" or "sticky" (by the request's key).
+	SignerSelection string
+	// (Optional) The minimum balance (in wei) of a signer for requests to be dispatched to it,
+	// checked at every status update.
+	MinSignerBalance *big.Int
+
+	// (Optional) The max gas fee cap (in wei) that any tx may be sent with. Tx requests may also
+	// have their own max.
+	MaxGasFeeCap *big.Int
+	// What to do with the tx requests whose tx would exceed its max gas fee cap: "reject"
+	// (default) to fail them, or "queue" to requeue them until the gas fees drop. Under either
+	// policy, a stale tx that is still pending stays in flight as is if its bumped gas fees would
+	// exceed the max.
+	MaxGasFeePolicy string
+	// How long to wait before requeueing the tx requests whose tx would exceed its max gas fee cap
+	// (ideally 1 block time). Ignored for SQS, which redelivers them after its visibility timeout.
+	MaxGasFeeRequeueDelay time.Duration
+
+	// How long the status of a tx request is kept once it is done (mined, reverted or failed).
+	// Defaults to 24 hours.
+	StatusRetention time.Duration
 }
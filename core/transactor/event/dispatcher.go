@@ -1,9 +1,13 @@
 package event
 
+import "sync"
+
 // Dispatcher is a generic event dispatcher. It maintains a mapping of unique indexes to
 // subscribers, which are channels that events are sent to.
 type Dispatcher[E any] struct {
 	subscribers map[int]chan E
+	nextIndex   int
+	mu          sync.RWMutex
 }
 
 // NewDispatcher creates a new Dispatcher.
@@ -14,21 +18,35 @@ func NewDispatcher[E any]() *Dispatcher[E] {
 }
 
 // Subscribe adds a new subscriber to the Dispatcher. The subscriber is a channel on which events
-// will be sent to. Returns the unique index of the subscriber.
+// will be sent to. Returns the unique index of the subscriber, which is never reused.
 func (d *Dispatcher[E]) Subscribe(subscriber chan E) int {
-	index := len(d.subscribers)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	index := d.nextIndex
+	d.nextIndex++
 	d.subscribers[index] = subscriber
 	return index
 }
 
 // Unsubscribe removes a subscriber from the Dispatcher at the given unique index.
 func (d *Dispatcher[E]) Unsubscribe(index int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	delete(d.subscribers, index)
 }
 
 // Dispatch sends an event to all subscribers.
 func (d *Dispatcher[E]) Dispatch(event E) {
+	d.mu.RLock()
+	subscribers := make([]chan E, 0, len(d.subscribers))
 	for _, subscriber := range d.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	d.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
 		subscriber <- event
 	}
 }
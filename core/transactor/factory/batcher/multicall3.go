@@ -21,7 +21,10 @@ const (
 	executionReverted = `execution reverted: `
 )
 
-var _ factory.Batcher = (*Multicall3)(nil)
+var (
+	_ factory.Batcher       = (*Multicall3)(nil)
+	_ factory.BatchUnpacker = (*Multicall3)(nil)
+)
 
 // Corresponds to the Multicall3 contract (https://www.multicall3.com), also dumped into
 // contracts/src/Multicall3.sol.
@@ -103,24 +106,43 @@ func (mc *Multicall3) BatchCallRequests(
 	}
 
 	// unpack the return data into call results
-	callResult, err := mc.packer.GetCallResult(tryAggregate, ret)
+	multicall3Results, err := mc.unpackResults(ret)
 	if err != nil {
 		sCtx.Logger().Error("failed to unpack call response", "err", err)
 		return nil, err
 	}
-	if len(callResult) != 1 {
-		err = fmt.Errorf("expected 1 list of Multicall3Results, got %d", len(callResult))
-		sCtx.Logger().Error("failed to unpack call response", "err", err)
+	return multicall3Results, nil
+}
+
+// UnpackBatchResults implements factory.BatchUnpacker.
+func (mc *Multicall3) UnpackBatchResults(ret []byte) ([]types.CallResult, error) {
+	multicall3Results, err := mc.unpackResults(ret)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]types.CallResult, len(multicall3Results))
+	for i, result := range multicall3Results {
+		results[i] = types.CallResult{Success: result.Success, ReturnData: result.ReturnData}
+	}
+	return results, nil
+}
+
+// unpackResults unpacks the return data of a `tryAggregate` call into Multicall3Results.
+func (mc *Multicall3) unpackResults(ret []byte) ([]bindings.Multicall3Result, error) {
+	callResult, err := mc.packer.GetCallResult(tryAggregate, ret)
+	if err != nil {
 		return nil, err
 	}
+	if len(callResult) != 1 {
+		return nil, fmt.Errorf("expected 1 list of Multicall3Results, got %d", len(callResult))
+	}
 	callResults, ok := callResult[0].([]struct {
 		Success    bool    "json:\"success\""
 		ReturnData []uint8 "json:\"returnData\""
 	})
 	if !ok {
-		err = errors.New("expected return type as list of Multicall3Results")
-		sCtx.Logger().Error("failed to unpack call response", "err", err)
-		return nil, err
+		return nil, errors.New("expected return type as list of Multicall3Results")
 	}
 
 	// convert the call responses into Multicall3Results
@@ -76,3 +76,28 @@ func TestMulticall3(t *testing.T) {
 	assert.Equal(t, 1, len(ret2))
 	assert.Equal(t, uint64(0), ret2[0].(*big.Int).Uint64())
 }
+
+// TestMulticall3UnpackBatchResults tests unpacking the result of each call from the return data of
+// a batched tx.
+func TestMulticall3UnpackBatchResults(t *testing.T) {
+	mc3Abi, err := bindings.Multicall3MetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := mc3Abi.Methods["tryAggregate"].Outputs.Pack([]bindings.Multicall3Result{
+		{Success: true, ReturnData: []byte{1}},
+		{Success: false, ReturnData: []byte{2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := batcher.NewMulticall3(empty).UnpackBatchResults(ret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []types.CallResult{
+		{Success: true, ReturnData: []byte{1}},
+		{Success: false, ReturnData: []byte{2}},
+	}, results)
+}
@@ -18,7 +18,10 @@ import (
 
 const multicall = `multicall`
 
-var _ factory.Batcher = (*Multicall3)(nil)
+var (
+	_ factory.Batcher       = (*PayableMulticall)(nil)
+	_ factory.BatchUnpacker = (*PayableMulticall)(nil)
+)
 
 // Corresponding to the PayableMulticall contract in contracts/lib/transient-goodies/src
 // (https://github.com/berachain/transient-goodies/blob/try-aggregate/src/PayableMulticallable.sol)
@@ -98,22 +101,41 @@ func (mc *PayableMulticall) BatchCallRequests(
 	}
 
 	// unpack the return data into call results
-	callResult, err := mc.packer.GetCallResult(multicall, ret)
+	callResults, err := mc.unpackResults(ret)
 	if err != nil {
 		sCtx.Logger().Error("failed to unpack call response", "err", err)
 		return nil, err
 	}
-	if len(callResult) != 1 {
-		err = fmt.Errorf("expected 1 list of [][]byte, got %d", len(callResult))
-		sCtx.Logger().Error("failed to unpack call response", "err", err)
+	return callResults, nil
+}
+
+// UnpackBatchResults implements factory.BatchUnpacker. Since the batched tx reverts if any call
+// fails, all the calls of a batched tx that did not revert are successful.
+func (mc *PayableMulticall) UnpackBatchResults(ret []byte) ([]types.CallResult, error) {
+	callResults, err := mc.unpackResults(ret)
+	if err != nil {
 		return nil, err
 	}
+
+	results := make([]types.CallResult, len(callResults))
+	for i, returnData := range callResults {
+		results[i] = types.CallResult{Success: true, ReturnData: returnData}
+	}
+	return results, nil
+}
+
+// unpackResults unpacks the return data of a `multicall` call into the return data of each call.
+func (mc *PayableMulticall) unpackResults(ret []byte) ([][]byte, error) {
+	callResult, err := mc.packer.GetCallResult(multicall, ret)
+	if err != nil {
+		return nil, err
+	}
+	if len(callResult) != 1 {
+		return nil, fmt.Errorf("expected 1 list of [][]byte, got %d", len(callResult))
+	}
 	callResults, ok := callResult[0].([][]byte)
 	if !ok {
-		err = errors.New("expected return type as list of bytes[]")
-		sCtx.Logger().Error("failed to unpack call response", "err", err)
-		return nil, err
+		return nil, errors.New("expected return type as list of bytes[]")
 	}
-
 	return callResults, nil
 }
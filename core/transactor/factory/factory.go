@@ -3,6 +3,7 @@ package factory
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -15,13 +16,18 @@ import (
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 )
 
+// ErrMaxGasFeeCapExceeded is returned when the gas fee cap of a transaction would exceed its max.
+var ErrMaxGasFeeCapExceeded = errors.New("gas fee cap exceeds the max gas fee cap")
+
 // Factory is a transaction factory that builds 1559 transactions with the configured signer.
 type Factory struct {
 	noncer                Noncer
 	signer                kmstypes.TxSigner
 	signTxTimeout         time.Duration
 	batcher               Batcher
-	defaultRequireSuccess bool // require success for all transactions in a batch
+	defaultRequireSuccess bool      // require success for all transactions in a batch
+	gasPricer             GasPricer // suggests the gas fees, if not provided
+	maxGasFeeCap          *big.Int  // the max gas fee cap of all transactions, if any
 
 	// caches
 	ethClient     eth.Client
@@ -48,16 +54,37 @@ func (f *Factory) SetClient(ethClient eth.Client) {
 	f.ethClient = ethClient
 }
 
+// SetGasPricer sets the gas pricer that suggests the gas fees of transactions whose requests do
+// not provide them. By default, the gas tip cap is suggested by the eth client and the gas fee
+// cap is (gasTipCap + 2 * basefee).
+func (f *Factory) SetGasPricer(gasPricer GasPricer) {
+	f.gasPricer = gasPricer
+}
+
+// SetMaxGasFeeCap sets the max gas fee cap of all transactions, above which they are not built.
+func (f *Factory) SetMaxGasFeeCap(maxGasFeeCap *big.Int) {
+	f.maxGasFeeCap = maxGasFeeCap
+}
+
 // BuildTransactionFromRequests builds a transaction from a list of requests.
 func (f *Factory) BuildTransactionFromRequests(
 	ctx context.Context, requests ...*ethereum.CallMsg,
+) (*coretypes.Transaction, error) {
+	return f.BuildCappedTransactionFromRequests(ctx, nil, requests...)
+}
+
+// BuildCappedTransactionFromRequests builds a transaction from a list of requests, if its gas fee
+// cap does not exceed the given max (if any) nor the factory's max (if any). Otherwise, returns
+// ErrMaxGasFeeCapExceeded.
+func (f *Factory) BuildCappedTransactionFromRequests(
+	ctx context.Context, maxGasFeeCap *big.Int, requests ...*ethereum.CallMsg,
 ) (*coretypes.Transaction, error) {
 	switch len(requests) {
 	case 0:
 		return nil, errors.New("no transaction requests provided")
 	case 1:
 		// if len(txReqs) == 1 then build a single transaction.
-		return f.buildTransaction(ctx, requests[0], 0)
+		return f.buildTransaction(ctx, requests[0], nil, maxGasFeeCap)
 	default:
 		// len(txReqs) > 1 then build a multicall transaction.
 		ar := f.batcher.BatchRequests(f.defaultRequireSuccess, requests...)
@@ -66,7 +93,7 @@ func (f *Factory) BuildTransactionFromRequests(
 		// ar.To should be the Multicall3 contract address
 		// ar.Data should be the calldata with the batched transactions.
 		// ar.Value is the sum of the values of the batched transactions.
-		return f.buildTransaction(ctx, ar.CallMsg, 0)
+		return f.buildTransaction(ctx, ar.CallMsg, nil, maxGasFeeCap)
 	}
 }
 
@@ -74,13 +101,22 @@ func (f *Factory) BuildTransactionFromRequests(
 func (f *Factory) RebuildTransactionFromRequest(
 	ctx context.Context, request *ethereum.CallMsg, forcedNonce uint64,
 ) (*coretypes.Transaction, error) {
-	return f.buildTransaction(ctx, request, forcedNonce)
+	return f.RebuildCappedTransactionFromRequest(ctx, nil, request, forcedNonce)
 }
 
-// buildTransaction builds a transaction with the configured signer. If nonce of 0 is provided,
-// a fresh nonce is acquired from the noncer.
+// RebuildCappedTransactionFromRequest rebuilds a transaction from a request with the forced nonce,
+// if its gas fee cap does not exceed the given max (if any) nor the factory's max (if any).
+// Otherwise, returns ErrMaxGasFeeCapExceeded.
+func (f *Factory) RebuildCappedTransactionFromRequest(
+	ctx context.Context, maxGasFeeCap *big.Int, request *ethereum.CallMsg, forcedNonce uint64,
+) (*coretypes.Transaction, error) {
+	return f.buildTransaction(ctx, request, &forcedNonce, maxGasFeeCap)
+}
+
+// buildTransaction builds a transaction with the configured signer, if its gas fee cap does not
+// exceed the max gas fee caps. If no nonce is provided, a fresh nonce is acquired from the noncer.
 func (f *Factory) buildTransaction(
-	ctx context.Context, callMsg *ethereum.CallMsg, nonce uint64,
+	ctx context.Context, callMsg *ethereum.CallMsg, forcedNonce *uint64, maxGasFeeCap *big.Int,
 ) (*coretypes.Transaction, error) {
 	var err error
 
@@ -92,45 +128,20 @@ func (f *Factory) buildTransaction(
 		}
 	}
 
-	// get the nonce from the noncer if not provided
-	var isReplacing bool
-	if nonce == 0 {
-		nonce, isReplacing = f.noncer.Acquire()
-	}
-
 	// start building the 1559 transaction
 	txData := &coretypes.DynamicFeeTx{
 		ChainID: f.chainID,
 		To:      callMsg.To,
 		Value:   callMsg.Value,
 		Data:    callMsg.Data,
-		Nonce:   nonce,
 	}
 
-	// set gas tip cap from eth client if not already provided
-	if callMsg.GasTipCap != nil {
-		txData.GasTipCap = callMsg.GasTipCap
-	} else {
-		txData.GasTipCap, err = f.ethClient.SuggestGasTipCap(ctx)
-		if err != nil {
-			return nil, err
-		}
+	// set the gas fees, rejecting them if they exceed the max gas fee caps
+	if txData.GasTipCap, txData.GasFeeCap, err = f.gasFees(ctx, callMsg); err != nil {
+		return nil, err
 	}
-
-	// set gas fee cap as (gasTipCap + 2 * basefee) if not already provided
-	if callMsg.GasFeeCap != nil {
-		txData.GasFeeCap = callMsg.GasFeeCap
-	} else {
-		var header *coretypes.Header
-		header, err = f.ethClient.HeaderByNumber(ctx, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		// use base fee wiggle multiplier of 2
-		txData.GasFeeCap = new(big.Int).Add(
-			txData.GasTipCap, new(big.Int).Mul(header.BaseFee, common.Big2),
-		)
+	if err = f.checkGasFeeCap(txData.GasFeeCap, maxGasFeeCap); err != nil {
+		return nil, err
 	}
 
 	// set gas limit from eth client if not already provided
@@ -143,10 +154,21 @@ func (f *Factory) buildTransaction(
 		}
 	}
 
-	// bump gas (if necessary)
+	// get the nonce from the noncer if not provided
+	var isReplacing bool
+	if forcedNonce != nil {
+		txData.Nonce = *forcedNonce
+	} else {
+		txData.Nonce, isReplacing = f.noncer.Acquire()
+	}
+
+	// bump gas (if necessary), rejecting the bumped gas fees if they exceed the max gas fee caps
 	tx := coretypes.NewTx(txData)
 	if isReplacing {
 		tx = sender.BumpGas(tx)
+		if err = f.checkGasFeeCap(tx.GasFeeCap(), maxGasFeeCap); err != nil {
+			return nil, err
+		}
 	}
 
 	// sign the transaction
@@ -158,3 +180,69 @@ func (f *Factory) buildTransaction(
 	}
 	return signer(f.signerAddress, tx)
 }
+
+// checkGasFeeCap returns ErrMaxGasFeeCapExceeded if the given gas fee cap exceeds the given max
+// (if any) or the factory's max (if any).
+func (f *Factory) checkGasFeeCap(gasFeeCap, maxGasFeeCap *big.Int) error {
+	for _, maxFeeCap := range []*big.Int{f.maxGasFeeCap, maxGasFeeCap} {
+		if maxFeeCap != nil && gasFeeCap.Cmp(maxFeeCap) > 0 {
+			return fmt.Errorf("%w: %s > %s", ErrMaxGasFeeCapExceeded, gasFeeCap, maxFeeCap)
+		}
+	}
+	return nil
+}
+
+// gasFees returns the gas tip cap and gas fee cap of the given request, suggesting the ones that
+// are not provided.
+func (f *Factory) gasFees(
+	ctx context.Context, callMsg *ethereum.CallMsg,
+) (*big.Int, *big.Int, error) {
+	if callMsg.GasTipCap != nil && callMsg.GasFeeCap != nil {
+		return callMsg.GasTipCap, callMsg.GasFeeCap, nil
+	}
+
+	// suggest the gas fees with the gas pricer, if any
+	if f.gasPricer != nil {
+		gasTipCap, gasFeeCap, err := f.gasPricer.SuggestGasFees(ctx, f.ethClient)
+		if err != nil {
+			return nil, nil, err
+		}
+		if callMsg.GasFeeCap != nil {
+			// the tip may not exceed the provided gas fee cap
+			if gasTipCap.Cmp(callMsg.GasFeeCap) > 0 {
+				gasTipCap = callMsg.GasFeeCap
+			}
+			return gasTipCap, callMsg.GasFeeCap, nil
+		}
+		if callMsg.GasTipCap != nil {
+			// keep the suggested base fee part of the gas fee cap, on top of the provided tip
+			gasFeeCap = new(big.Int).Add(
+				callMsg.GasTipCap, new(big.Int).Sub(gasFeeCap, gasTipCap),
+			)
+			return callMsg.GasTipCap, gasFeeCap, nil
+		}
+		return gasTipCap, gasFeeCap, nil
+	}
+
+	// set gas tip cap from eth client if not already provided
+	gasTipCap := callMsg.GasTipCap
+	if gasTipCap == nil {
+		var err error
+		if gasTipCap, err = f.ethClient.SuggestGasTipCap(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// set gas fee cap as (gasTipCap + 2 * basefee) if not already provided
+	gasFeeCap := callMsg.GasFeeCap
+	if gasFeeCap == nil {
+		header, err := f.ethClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// use base fee wiggle multiplier of 2
+		gasFeeCap = new(big.Int).Add(gasTipCap, new(big.Int).Mul(header.BaseFee, common.Big2))
+	}
+	return gasTipCap, gasFeeCap, nil
+}
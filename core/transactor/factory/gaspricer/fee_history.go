@@ -0,0 +1,75 @@
+package gaspricer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Speed is the percentile of the priority fees paid in recent blocks that a tip is suggested at.
+type Speed float64
+
+const (
+	Slow     Speed = 10
+	Standard Speed = 50
+	Fast     Speed = 90
+)
+
+// defaultBlockCount is the default number of recent blocks that the fee history is fetched for.
+const defaultBlockCount = 20
+
+var _ factory.GasPricer = (*FeeHistory)(nil)
+
+// FeeHistory suggests gas fees from the fee history of recent blocks: the gas tip cap is the
+// median of the blocks' priority fees at the given speed (percentile), and the gas fee cap is the
+// tip plus 2 * the base fee of the next block, to remain valid if the base fee keeps rising.
+type FeeHistory struct {
+	speed      Speed
+	blockCount uint64
+}
+
+// NewFeeHistory creates a new FeeHistory gas pricer at the given speed, over the given number of
+// recent blocks (20 if 0).
+func NewFeeHistory(speed Speed, blockCount uint64) *FeeHistory {
+	if blockCount == 0 {
+		blockCount = defaultBlockCount
+	}
+	return &FeeHistory{speed: speed, blockCount: blockCount}
+}
+
+// SuggestGasFees implements factory.GasPricer.
+func (fh *FeeHistory) SuggestGasFees(
+	ctx context.Context, chain eth.Client,
+) (*big.Int, *big.Int, error) {
+	history, err := chain.FeeHistory(ctx, fh.blockCount, nil, []float64{float64(fh.speed)})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, nil, errors.New("no base fees in the fee history")
+	}
+
+	// Use the median of the (non-empty) blocks' rewards at the percentile as the tip.
+	tips := make([]*big.Int, 0, len(history.Reward))
+	for _, reward := range history.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			tips = append(tips, reward[0])
+		}
+	}
+	gasTipCap := new(big.Int)
+	if len(tips) > 0 {
+		slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+		gasTipCap.Set(tips[len(tips)/2])
+	}
+
+	// The last base fee is the one of the next block.
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(baseFee, common.Big2))
+	return gasTipCap, gasFeeCap, nil
+}
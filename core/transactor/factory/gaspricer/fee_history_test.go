@@ -0,0 +1,31 @@
+package gaspricer_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/berachain/offchain-sdk/core/transactor/factory/gaspricer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+func TestFeeHistory(t *testing.T) {
+	chain := mocks.NewClient(t)
+	chain.On("FeeHistory", mock.Anything, uint64(3), (*big.Int)(nil), []float64{90}).Return(
+		&ethereum.FeeHistory{
+			Reward:  [][]*big.Int{{big.NewInt(3)}, {big.NewInt(1)}, {big.NewInt(2)}},
+			BaseFee: []*big.Int{big.NewInt(8), big.NewInt(9), big.NewInt(11), big.NewInt(10)},
+		}, nil,
+	)
+
+	// The tip is the median reward, and the fee cap is the tip + 2 * the next block's base fee.
+	gasTipCap, gasFeeCap, err := gaspricer.NewFeeHistory(gaspricer.Fast, 3).
+		SuggestGasFees(context.Background(), chain)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), gasTipCap)
+	require.Equal(t, big.NewInt(22), gasFeeCap)
+}
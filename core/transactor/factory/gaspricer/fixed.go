@@ -0,0 +1,27 @@
+package gaspricer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
+)
+
+var _ factory.GasPricer = (*Fixed)(nil)
+
+// Fixed always suggests the same gas fees.
+type Fixed struct {
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+}
+
+// NewFixed creates a new Fixed gas pricer with the given gas tip cap and gas fee cap.
+func NewFixed(gasTipCap, gasFeeCap *big.Int) *Fixed {
+	return &Fixed{gasTipCap: gasTipCap, gasFeeCap: gasFeeCap}
+}
+
+// SuggestGasFees implements factory.GasPricer.
+func (f *Fixed) SuggestGasFees(context.Context, eth.Client) (*big.Int, *big.Int, error) {
+	return new(big.Int).Set(f.gasTipCap), new(big.Int).Set(f.gasFeeCap), nil
+}
@@ -0,0 +1,19 @@
+package gaspricer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
+)
+
+var _ factory.GasPricer = (Oracle)(nil)
+
+// Oracle suggests the gas fees returned by a callback, e.g. from an external gas oracle.
+type Oracle func(ctx context.Context) (gasTipCap, gasFeeCap *big.Int, err error)
+
+// SuggestGasFees implements factory.GasPricer.
+func (o Oracle) SuggestGasFees(ctx context.Context, _ eth.Client) (*big.Int, *big.Int, error) {
+	return o(ctx)
+}
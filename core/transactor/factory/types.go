@@ -2,7 +2,9 @@ package factory
 
 import (
 	"context"
+	"math/big"
 
+	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 
 	"github.com/ethereum/go-ethereum"
@@ -25,3 +27,16 @@ type Batcher interface {
 		callReqs ...*ethereum.CallMsg,
 	) (any, error)
 }
+
+// GasPricer is an interface for suggesting the gas fees of EIP-1559 transactions.
+type GasPricer interface {
+	// SuggestGasFees returns the suggested gas tip cap and gas fee cap for a transaction.
+	SuggestGasFees(ctx context.Context, chain eth.Client) (*big.Int, *big.Int, error)
+}
+
+// BatchUnpacker is optionally implemented by a Batcher to unpack the result of each call from the
+// return data of a batched transaction.
+type BatchUnpacker interface {
+	// UnpackBatchResults returns the result of each call, in order, from the given return data.
+	UnpackBatchResults(ret []byte) ([]types.CallResult, error)
+}
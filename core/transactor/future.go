@@ -0,0 +1,152 @@
+package transactor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Result is the outcome of a single tx request.
+type Result struct {
+	MsgID string
+
+	// Receipt is the receipt of the tx that the request was included in, if it was mined or
+	// reverted.
+	Receipt *coretypes.Receipt
+	// Call is the result of the request's own call in the tx, e.g. its entry in the multicall
+	// result if batched. It is best-effort, from replaying the tx, and nil if unknown.
+	Call *types.CallResult
+	// RevertReason is the decoded reason that the tx reverted with, if known.
+	RevertReason string
+
+	// Err is the error that the request failed to be built or sent with, or that its tx went
+	// stale with, if any.
+	Err error
+}
+
+// Reverted returns whether the tx that the request was included in reverted.
+func (r *Result) Reverted() bool {
+	return r.Receipt != nil && r.Receipt.Status != coretypes.ReceiptStatusSuccessful
+}
+
+// Callback is called with the result of a tx request, once it is done.
+type Callback func(*Result)
+
+// Future is the awaitable result of a tx request.
+type Future struct {
+	msgID  string
+	done   chan struct{}
+	result *Result
+}
+
+// MsgID returns the message ID of the tx request.
+func (f *Future) MsgID() string {
+	return f.msgID
+}
+
+// Done returns a channel that is closed once the tx request is done.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the tx request is done and returns its result, or until the context is done.
+func (f *Future) Await(ctx context.Context) (*Result, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-f.done:
+		return f.result, nil
+	}
+}
+
+// SendTxRequestFuture adds the given tx request to the tx queue like SendTxRequest, returning a
+// future for its result.
+func (t *TxrV2) SendTxRequestFuture(txReq *types.Request) (*Future, error) {
+	f := &Future{done: make(chan struct{})}
+	msgID, err := t.SendTxRequest(txReq, func(res *Result) {
+		f.result = res
+		close(f.done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.msgID = msgID
+	return f, nil
+}
+
+// addCallbacks registers the given callbacks for the tx request with the given message ID. It
+// must be called with the callbacks lock held.
+func (t *TxrV2) addCallbacks(msgID string, callbacks []Callback) {
+	if len(callbacks) > 0 {
+		t.callbacks[msgID] = append(t.callbacks[msgID], callbacks...)
+	}
+}
+
+// complete calls the callbacks of the requests of the given tx response, once it is done, with
+// the given receipt (if mined or reverted) and revert reason (if any).
+func (t *TxrV2) complete(
+	ctx context.Context, resp *tracker.Response, receipt *coretypes.Receipt, revertReason string,
+) {
+	t.callbacksMu.Lock()
+	callbacks := make(map[string][]Callback)
+	for _, msgID := range resp.MsgIDs {
+		if cbs, ok := t.callbacks[msgID]; ok {
+			callbacks[msgID] = cbs
+			delete(t.callbacks, msgID)
+		}
+	}
+	t.callbacksMu.Unlock()
+	if len(callbacks) == 0 {
+		return
+	}
+
+	var calls []types.CallResult
+	if receipt != nil && receipt.Status == coretypes.ReceiptStatusSuccessful {
+		calls = t.callResults(ctx, resp, receipt)
+	}
+	for i, msgID := range resp.MsgIDs {
+		res := &Result{
+			MsgID: msgID, Receipt: receipt, RevertReason: revertReason, Err: resp.Error,
+		}
+		if i < len(calls) {
+			res.Call = &calls[i]
+		}
+		for _, cb := range callbacks[msgID] {
+			go cb(res)
+		}
+	}
+}
+
+// callResults returns the result of each request's call in the given mined tx, by replaying it.
+// The results of a batched tx are unpacked by the batcher, if it supports it.
+func (t *TxrV2) callResults(
+	ctx context.Context, resp *tracker.Response, receipt *coretypes.Receipt,
+) []types.CallResult {
+	ret, err := t.replay(ctx, resp.Transaction, receipt)
+	if err != nil {
+		t.logger.Debug("failed to replay tx for call results", "tx-hash", resp.Hash(), "err", err)
+		return nil
+	}
+	if len(resp.MsgIDs) == 1 {
+		return []types.CallResult{{Success: true, ReturnData: ret}}
+	}
+
+	unpacker, ok := t.batcher.(factory.BatchUnpacker)
+	if !ok {
+		return nil
+	}
+	calls, err := unpacker.UnpackBatchResults(ret)
+	if err == nil && len(calls) != len(resp.MsgIDs) {
+		err = errors.New("unexpected number of call results")
+	}
+	if err != nil {
+		t.logger.Debug("failed to unpack call results", "tx-hash", resp.Hash(), "err", err)
+		return nil
+	}
+	return calls
+}
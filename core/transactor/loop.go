@@ -2,8 +2,11 @@ package transactor
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
+	"github.com/berachain/offchain-sdk/core/transactor/store"
 	"github.com/berachain/offchain-sdk/core/transactor/tracker"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 
@@ -17,6 +20,13 @@ func (t *TxrV2) mainLoop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		default:
+			// Wait for a signer to be available before retrieving requests from the queue.
+			if len(t.signers.available()) == 0 {
+				t.logger.Warn("no signer available to process tx requests...")
+				time.Sleep(t.cfg.EmptyQueueDelay)
+				continue
+			}
+
 			// Attempt the retrieve a batch from the queue.
 			requests := t.retrieveBatch(ctx)
 			if len(requests) == 0 {
@@ -26,11 +36,23 @@ func (t *TxrV2) mainLoop(ctx context.Context) {
 				continue
 			}
 
-			// We got a batch, so we can build and fire, after the previous fire has finished.
-			go t.fire(
-				ctx, &tracker.Response{MsgIDs: requests.MsgIDs(), InitialTimes: requests.Times()},
-				true, requests.Messages()...,
-			)
+			// We got a batch, so we can build and fire from its signer(s), in the order the batches
+			// of each signer are dispatched. If no signer is available anymore, the requests are
+			// requeued.
+			for _, batch := range t.signers.assign(requests) {
+				resp := &tracker.Response{
+					MsgIDs: batch.requests.MsgIDs(), InitialTimes: batch.requests.Times(),
+					MaxGasFeeCap: batch.requests.MaxGasFeeCap(),
+				}
+				fire := func(ctx context.Context) {
+					t.fire(ctx, batch.signer, resp, true, batch.requests.Messages()...)
+				}
+				if batch.signer == nil {
+					go fire(ctx)
+					continue
+				}
+				batch.signer.dispatch(ctx, fire)
+			}
 		}
 	}
 }
@@ -72,6 +94,10 @@ func (t *TxrV2) retrieveBatch(ctx context.Context) types.Requests {
 				continue
 			}
 
+			// Wait for the requests that are being pushed to be registered.
+			t.pushMu.Lock()
+			t.pushMu.Unlock() //nolint:staticcheck // only a barrier.
+
 			// If using the queue message ID, we need to update the message ID for each tx request.
 			if t.cfg.UseQueueMessageID {
 				for i, txReq := range txReqs {
@@ -79,42 +105,111 @@ func (t *TxrV2) retrieveBatch(ctx context.Context) types.Requests {
 				}
 			}
 
-			// Append the tx requests for retrieval.
-			requests = append(requests, txReqs...)
+			// Append the tx requests for retrieval, skipping the ones that were already sent, and
+			// deleting the ones that are done from the queue.
+			for _, txReq := range txReqs {
+				sent, done := t.alreadySent(ctx, txReq.MsgID)
+				if !sent {
+					requests = append(requests, txReq)
+					continue
+				}
+				t.logger.Debug("skipping tx request that was already sent", "msg", txReq.MsgID)
+				if done {
+					if err = t.requests.Delete(txReq.MsgID); err != nil {
+						t.logger.Error(
+							"error deleting request from queue", "id", txReq.MsgID, "err", err,
+						)
+					}
+				}
+			}
 		}
 	}
 }
 
-// fire processes the tracked tx response. If requested to build, it will first batch the messages.
-// Then it sends the batch as one tx and asynchronously tracks the tx for its status. Will return
-// early and notify tx subscribers if an error occurs during building or sending.
-// NOTE: if `toBuild` is false, resp.Transaction must be a valid, signed tx.
-// NOTE: this function blocks until any previous calls to `fire` are completed.
+// fire processes the tracked tx response from the given signer. If requested to build, it will
+// first batch the messages. Then it sends the batch as one tx and asynchronously tracks the tx for
+// its status. Will return early and notify tx subscribers if an error occurs during building or
+// sending. If no signer is available, the requests are requeued if possible, or else failed.
+// NOTE: if `toBuild` is false, resp.Transaction must be a valid, signed tx from the signer.
+// NOTE: this function blocks until any previous calls to `fire` from the signer are completed.
 func (t *TxrV2) fire(
-	ctx context.Context, resp *tracker.Response, toBuild bool, msgs ...*ethereum.CallMsg,
+	ctx context.Context, s *signer, resp *tracker.Response, toBuild bool,
+	msgs ...*ethereum.CallMsg,
 ) {
-	t.senderMu.Lock()
-	defer t.senderMu.Unlock()
+	if s == nil {
+		resp.Error = errNoSigner
+		if toBuild && t.requeueLater(ctx, resp, msgs, t.cfg.EmptyQueueDelay) {
+			t.logger.Warn("no signer available, requeueing tx requests", "msgs", resp.MsgIDs)
+			return
+		}
+		t.dispatcher.Dispatch(resp)
+		return
+	}
+	s.senderMu.Lock()
+	defer s.senderMu.Unlock()
+	resp.Signer = s.addr
 
 	if toBuild {
 		// Call the factory to build the (batched) transaction.
 		t.markState(types.StateBuilding, resp.MsgIDs...)
-		resp.Transaction, resp.Error = t.factory.BuildTransactionFromRequests(ctx, msgs...)
+		t.recordStatus(ctx, resp, store.StatusBuilding)
+		resp.Transaction, resp.Error = s.factory.BuildCappedTransactionFromRequests(
+			ctx, resp.MaxGasFeeCap, msgs...,
+		)
 		if resp.Error != nil {
-			t.dispatcher.Dispatch(resp)
+			if !t.requeueOverpaying(ctx, resp, msgs) {
+				t.dispatcher.Dispatch(resp)
+			}
 			return
 		}
 	}
 
 	// Call the sender to send the transaction to the chain.
 	t.markState(types.StateSending, resp.MsgIDs...)
-	if resp.Error = t.sender.SendTransaction(ctx, resp.Transaction); resp.Error != nil {
+	t.recordStatus(ctx, resp, store.StatusSending)
+	// The sender may have replaced the tx, e.g. with a fresh nonce, so the tx it sent last is the
+	// one whose hash is recorded and that is tracked.
+	sent, err := s.sender.SendTransaction(ctx, resp.Transaction, resp.MaxGasFeeCap)
+	if sent != nil {
+		resp.Transaction = sent
+	}
+	if resp.Error = err; resp.Error != nil {
+		if t.requeueOverpaying(ctx, resp, msgs) {
+			s.noncer.RemoveAcquired(resp.Nonce())
+			return
+		}
 		t.dispatcher.Dispatch(resp)
 		return
 	}
-	t.logger.Info("📡 sent transaction", "hash", resp.Hash().Hex(), "reqs", len(resp.MsgIDs))
+	t.logger.Info(
+		"📡 sent transaction", "hash", resp.Hash().Hex(), "signer", s.addr, "reqs",
+		len(resp.MsgIDs),
+	)
+	t.track(ctx, s, resp)
+}
 
-	// Call the tracker to track the transaction async.
+// track calls the tracker of the given signer to track the sent tx of the response async.
+func (t *TxrV2) track(ctx context.Context, s *signer, resp *tracker.Response) {
 	t.markState(types.StateInFlight, resp.MsgIDs...)
-	t.tracker.Track(ctx, resp)
+	t.recordStatus(ctx, resp, store.StatusInFlight)
+	s.tracked.Add(1)
+	s.tracker.Track(ctx, resp)
+}
+
+// requeueOverpaying requeues the requests of the given response instead of failing them, if their
+// tx would exceed its max gas fee cap and the max gas fee policy is to queue. Returns whether the
+// requests were requeued.
+func (t *TxrV2) requeueOverpaying(
+	ctx context.Context, resp *tracker.Response, msgs []*ethereum.CallMsg,
+) bool {
+	if !errors.Is(resp.Error, factory.ErrMaxGasFeeCapExceeded) ||
+		t.cfg.MaxGasFeePolicy != MaxGasFeeQueue ||
+		!t.requeueLater(ctx, resp, msgs, t.cfg.MaxGasFeeRequeueDelay) {
+		return false
+	}
+	t.logger.Warn(
+		"⛽ gas fees exceed the max, requeueing tx requests", "err", resp.Error,
+		"msgs", resp.MsgIDs,
+	)
+	return true
 }
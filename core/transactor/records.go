@@ -0,0 +1,446 @@
+package transactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/core/transactor/store"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	StateStoreDB  = "db"
+	StateStoreSQL = "sql"
+)
+
+var (
+	// errNoSigner is returned when no signer is available to send a tx, e.g. all are draining.
+	errNoSigner = errors.New("no signer available")
+	// errNoReplay is returned when a tx cannot be replayed, e.g. before the transactor is set up.
+	errNoReplay = errors.New("tx cannot be replayed")
+)
+
+// SetStateStore sets the store that the lifecycle of every tx request is persisted in, instead of
+// the one from the config. It must be called before the transactor is set up.
+func (t *TxrV2) SetStateStore(s store.Store) {
+	t.store = s
+}
+
+// setupStore builds the state store from the config, unless one was set, using the given app DB
+// for the "db" state store. If none is configured, the state is only stored in memory.
+func (t *TxrV2) setupStore(ctx context.Context, db ethdb.KeyValueStore) error {
+	if t.store != nil {
+		return nil
+	}
+
+	switch t.cfg.StateStore {
+	case "":
+		t.store = store.NewKVStore(memorydb.New())
+	case StateStoreDB:
+		if db == nil {
+			return fmt.Errorf("state store %q requires the app to have a DB", StateStoreDB)
+		}
+		t.store = store.NewKVStore(db)
+	case StateStoreSQL:
+		sqlDB, err := sql.Open(t.cfg.StateStoreSQLDriver, t.cfg.StateStoreSQLDSN)
+		if err != nil {
+			return err
+		}
+		if t.store, err = store.NewSQLStore(ctx, sqlDB); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown state store %q", t.cfg.StateStore)
+	}
+	return nil
+}
+
+// recordRequest persists a new tx request with the given status. It must be called with the store
+// lock held.
+func (t *TxrV2) recordRequest(
+	ctx context.Context, msgID string, txReq *types.Request, status store.Status,
+) {
+	if t.store == nil || msgID == "" {
+		return
+	}
+
+	now := time.Now()
+	if err := t.store.Put(ctx, &store.Record{
+		MsgID:        msgID,
+		Status:       status,
+		History:      []store.Event{{Status: status, Time: now}},
+		Request:      txReq.CallMsg,
+		Key:          txReq.Key,
+		MaxGasFeeCap: txReq.MaxGasFeeCap,
+		CreatedAt:    txReq.Time(),
+		UpdatedAt:    now,
+	}); err != nil {
+		t.logger.Error("failed to persist tx request", "msg", msgID, "err", err)
+	}
+}
+
+// recordStatus persists the given status of the requests of the given tx response, along with
+// its tx (if built) and error (if any).
+func (t *TxrV2) recordStatus(ctx context.Context, resp *tracker.Response, status store.Status) {
+	t.updateRecords(ctx, resp, status, func(*store.Record) {})
+}
+
+// recordOutcome persists the outcome of the requests of the given tx response, once its tx was
+// mined or reverted, along with its receipt and revert reason (if any).
+func (t *TxrV2) recordOutcome(
+	ctx context.Context, resp *tracker.Response, receipt *coretypes.Receipt, revertReason string,
+) {
+	status := store.StatusMined
+	if receipt.Status != coretypes.ReceiptStatusSuccessful {
+		status = store.StatusReverted
+	}
+	if receipt.Logs == nil {
+		// The logs are required to decode the persisted receipt.
+		cpy := *receipt
+		cpy.Logs = []*coretypes.Log{}
+		receipt = &cpy
+	}
+	t.updateRecords(ctx, resp, status, func(rec *store.Record) {
+		rec.Receipt, rec.RevertReason = receipt, revertReason
+	})
+}
+
+// updateRecords persists the given status of the requests of the given tx response, appending it
+// to their history, and applies the given update to their records.
+func (t *TxrV2) updateRecords(
+	ctx context.Context, resp *tracker.Response, status store.Status,
+	update func(*store.Record),
+) {
+	if t.store == nil {
+		return
+	}
+	t.storeMu.Lock()
+	defer t.storeMu.Unlock()
+
+	now := time.Now()
+	for _, msgID := range resp.MsgIDs {
+		rec, err := t.store.Get(ctx, msgID)
+		if err != nil {
+			t.logger.Error("failed to get persisted tx request", "msg", msgID, "err", err)
+			continue
+		} else if rec == nil {
+			continue
+		}
+
+		rec.Status, rec.UpdatedAt = status, now
+		if resp.Transaction != nil && rec.LatestTxHash() != resp.Hash() {
+			rec.Signer, rec.Nonce = resp.Signer, resp.Nonce()
+			rec.TxHashes = append(rec.TxHashes, resp.Hash())
+		}
+		if resp.Error != nil {
+			rec.Error = resp.Error.Error()
+		}
+		rec.History = append(rec.History, store.Event{
+			Status: status, TxHash: resp.Hash(), Error: rec.Error, Time: now,
+		})
+		update(rec)
+
+		if err = t.store.Put(ctx, rec); err != nil {
+			t.logger.Error("failed to persist tx request", "msg", msgID, "err", err)
+		}
+	}
+}
+
+// revertReason returns the reason that the given reverted tx reverted with, by replaying it. The
+// reason is decoded if it is a revert string, or else returned as the hex-encoded revert data. It
+// is empty if the tx does not revert when replayed.
+func (t *TxrV2) revertReason(
+	ctx context.Context, tx *coretypes.Transaction, receipt *coretypes.Receipt,
+) string {
+	_, err := t.replay(ctx, tx, receipt)
+	if err == nil || errors.Is(err, errNoReplay) {
+		return ""
+	}
+
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err.Error()
+	}
+	data, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err.Error()
+	}
+	if reason, unpackErr := abi.UnpackRevert(common.FromHex(data)); unpackErr == nil {
+		return reason
+	}
+	return data
+}
+
+// replay replays the given mined tx as a call on the state of its block's parent, returning its
+// return data. This is best-effort, since the txs that preceded it in its block are not applied.
+func (t *TxrV2) replay(
+	ctx context.Context, tx *coretypes.Transaction, receipt *coretypes.Receipt,
+) ([]byte, error) {
+	if t.chain == nil || tx == nil || receipt.BlockNumber == nil {
+		return nil, errNoReplay
+	}
+
+	msg := types.CallMsgFromTx(tx)
+	msg.From, _ = coretypes.Sender(coretypes.LatestSignerForChainID(tx.ChainId()), tx)
+	return t.chain.CallContract(ctx, *msg, new(big.Int).Sub(receipt.BlockNumber, common.Big1))
+}
+
+// pruneRecords deletes the records of the requests that have been done for longer than the
+// configured retention.
+func (t *TxrV2) pruneRecords(ctx context.Context) {
+	if t.store == nil {
+		return
+	}
+	if err := t.store.Prune(ctx, time.Now().Add(-t.cfg.StatusRetention)); err != nil {
+		t.logger.Error("failed to prune persisted tx requests", "err", err)
+	}
+}
+
+// alreadySent returns whether the given request was already sent in a tx, according to its
+// persisted record, and whether it is done. This is the case for a request that is received again
+// from the queue, e.g. since its tx was not confirmed before the queue's visibility timeout or a
+// restart. Requests that failed before being sent in a tx are not, so that they are retried.
+func (t *TxrV2) alreadySent(ctx context.Context, msgID string) (bool, bool) {
+	if t.store == nil || msgID == "" {
+		return false, false
+	}
+	rec, err := t.store.Get(ctx, msgID)
+	if err != nil {
+		t.logger.Error("failed to get persisted tx request", "msg", msgID, "err", err)
+		return false, false
+	}
+	if rec == nil || (rec.Status == store.StatusFailed && len(rec.TxHashes) == 0) {
+		return false, false
+	}
+	return len(rec.TxHashes) > 0 || rec.Status.Done(), rec.Status.Done()
+}
+
+// maxGasFeeCapOf returns the lowest max gas fee cap of the persisted requests with the given
+// message IDs, or nil if none has one.
+func (t *TxrV2) maxGasFeeCapOf(ctx context.Context, msgIDs []string) *big.Int {
+	if t.store == nil {
+		return nil
+	}
+	var maxGasFeeCap *big.Int
+	for _, msgID := range msgIDs {
+		rec, err := t.store.Get(ctx, msgID)
+		if err != nil {
+			t.logger.Error("failed to get persisted tx request", "msg", msgID, "err", err)
+			continue
+		}
+		if rec != nil && rec.MaxGasFeeCap != nil &&
+			(maxGasFeeCap == nil || rec.MaxGasFeeCap.Cmp(maxGasFeeCap) < 0) {
+			maxGasFeeCap = rec.MaxGasFeeCap
+		}
+	}
+	return maxGasFeeCap
+}
+
+// reconcile reconciles the persisted requests that were not done before a restart against the
+// chain:
+//   - requests whose tx was included in a block are marked as mined or reverted,
+//   - requests whose tx is still pending in the mempool are returned by the tx's signer and
+//     nonce, to be resent with a bumped gas along with the other stale txs,
+//   - requests whose tx is not found and whose nonce is not used yet were dropped, so they are
+//     rebuilt and resent,
+//   - requests whose nonce was used by an unknown tx are failed, since resending them could
+//     execute them twice,
+//   - requests that were not sent yet are requeued, unless the queue is persistent itself.
+//
+// NOTE: blocks until all the rebuilt txs either error and/or are sent to the chain.
+func (t *TxrV2) reconcile(
+	ctx context.Context, chain eth.Client,
+) (map[common.Address]map[uint64][]string, error) {
+	var (
+		recs []*store.Record
+		err  error
+	)
+	if t.store != nil {
+		if recs, err = t.store.Pending(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		pending   = make(map[common.Address]map[uint64][]string)
+		dropped   = make(map[common.Hash][]*store.Record)
+		nonces    = make(map[common.Address]uint64)
+		txHashes  []common.Hash
+		persisted = t.cfg.SQS.QueueURL != ""
+	)
+	for _, rec := range recs {
+		if len(rec.TxHashes) == 0 {
+			if !persisted {
+				t.requeue(ctx, rec)
+			}
+			continue
+		}
+
+		if receipt := t.findReceipt(ctx, chain, rec.TxHashes); receipt != nil {
+			resp := &tracker.Response{MsgIDs: []string{rec.MsgID}}
+			if receipt.Status == coretypes.ReceiptStatusSuccessful {
+				t.recordOutcome(ctx, resp, receipt, "")
+			} else {
+				tx, _, _ := chain.TransactionByHash(ctx, receipt.TxHash)
+				t.recordOutcome(ctx, resp, receipt, t.revertReason(ctx, tx, receipt))
+			}
+			if err = t.requests.Delete(rec.MsgID); err != nil {
+				t.logger.Error("error deleting request from queue", "id", rec.MsgID, "err", err)
+			}
+			continue
+		}
+
+		_, isPending, txErr := chain.TransactionByHash(ctx, rec.LatestTxHash())
+		switch {
+		case txErr == nil && isPending:
+			if pending[rec.Signer] == nil {
+				pending[rec.Signer] = make(map[uint64][]string)
+			}
+			pending[rec.Signer][rec.Nonce] = append(pending[rec.Signer][rec.Nonce], rec.MsgID)
+			continue
+		case txErr == nil:
+			// The tx was included, but its receipt is not available yet.
+			t.logger.Warn("receipt of tx not found, reconciling after restart",
+				"msg", rec.MsgID, "hash", rec.LatestTxHash())
+			continue
+		case !errors.Is(txErr, ethereum.NotFound):
+			return nil, fmt.Errorf("get tx %s: %w", rec.LatestTxHash(), txErr)
+		}
+
+		// The tx is not found, so it was dropped unless its nonce was used by another tx.
+		nonce, ok := nonces[rec.Signer]
+		if !ok {
+			if nonce, err = chain.NonceAt(ctx, rec.Signer, nil); err != nil {
+				return nil, fmt.Errorf("get nonce of signer %s: %w", rec.Signer, err)
+			}
+			nonces[rec.Signer] = nonce
+		}
+		if rec.Nonce < nonce {
+			resp := &tracker.Response{
+				MsgIDs: []string{rec.MsgID},
+				Error:  fmt.Errorf("nonce %d of tx %s used by another tx", rec.Nonce, rec.LatestTxHash()),
+			}
+			t.logger.Error("❌ failing tx request", "msg", rec.MsgID, "err", resp.Error)
+			t.recordStatus(ctx, resp, store.StatusFailed)
+			continue
+		}
+		if _, ok = dropped[rec.LatestTxHash()]; !ok {
+			txHashes = append(txHashes, rec.LatestTxHash())
+		}
+		dropped[rec.LatestTxHash()] = append(dropped[rec.LatestTxHash()], rec)
+	}
+
+	// Rebuild the dropped txs, with the same requests in each, preferably from the same signer.
+	for _, txHash := range txHashes {
+		resp := &tracker.Response{Signer: dropped[txHash][0].Signer}
+		msgs := make([]*ethereum.CallMsg, 0, len(dropped[txHash]))
+		for _, rec := range dropped[txHash] {
+			resp.MsgIDs = append(resp.MsgIDs, rec.MsgID)
+			resp.InitialTimes = append(resp.InitialTimes, rec.CreatedAt)
+			msgs = append(msgs, rec.Request)
+		}
+		t.logger.Info("🔄 resending dropped txs", "hash", txHash.Hex(), "reqs", len(msgs))
+		t.fire(ctx, t.signers.prefer(resp.Signer), resp, true, msgs...)
+	}
+
+	return pending, nil
+}
+
+// findReceipt returns the receipt of the first of the given txs that was included in a block, if
+// any.
+func (t *TxrV2) findReceipt(
+	ctx context.Context, chain eth.Client, txHashes []common.Hash,
+) *coretypes.Receipt {
+	for _, txHash := range txHashes {
+		if receipt, err := chain.TransactionReceipt(ctx, txHash); err == nil {
+			return receipt
+		}
+	}
+	return nil
+}
+
+// requeue pushes the persisted request back onto the queue, under its new message ID if the queue
+// message ID is used.
+func (t *TxrV2) requeue(ctx context.Context, rec *store.Record) {
+	// The request may be registered under its new message ID only once pushed, see SendTxRequest.
+	t.pushMu.RLock()
+	defer t.pushMu.RUnlock()
+
+	txReq := types.RestoreRequest(rec.Request, rec.MsgID, rec.CreatedAt)
+	txReq.Key, txReq.MaxGasFeeCap = rec.Key, rec.MaxGasFeeCap
+	queueID, err := t.requests.Push(txReq)
+	if err != nil {
+		t.logger.Error("failed to requeue persisted tx request", "msg", rec.MsgID, "err", err)
+		return
+	}
+
+	t.storeMu.Lock()
+	defer t.storeMu.Unlock()
+
+	now := time.Now()
+	rec.Status, rec.UpdatedAt = store.StatusQueued, now
+	rec.History = append(rec.History, store.Event{Status: store.StatusQueued, Time: now})
+	if t.cfg.UseQueueMessageID && queueID != rec.MsgID {
+		if err = t.store.Delete(ctx, rec.MsgID); err != nil {
+			t.logger.Error("failed to delete persisted tx request", "msg", rec.MsgID, "err", err)
+		}
+		t.callbacksMu.Lock()
+		t.addCallbacks(queueID, t.callbacks[rec.MsgID])
+		delete(t.callbacks, rec.MsgID)
+		t.callbacksMu.Unlock()
+		rec.MsgID = queueID
+	}
+	if err = t.store.Put(ctx, rec); err != nil {
+		t.logger.Error("failed to persist tx request", "msg", rec.MsgID, "err", err)
+	}
+	t.markState(types.StateQueued, rec.MsgID)
+}
+
+// requeueLater requeues the requests of the given response after the given delay, e.g. once their
+// tx would no longer exceed its max gas fee cap, or once a signer is available. The requests that
+// are not persisted are requeued with the max gas fee cap of the tx. Returns false if the requests
+// cannot be requeued, i.e. if their call msgs are not known individually.
+func (t *TxrV2) requeueLater(
+	ctx context.Context, resp *tracker.Response, msgs []*ethereum.CallMsg, delay time.Duration,
+) bool {
+	if len(msgs) != len(resp.MsgIDs) || len(msgs) != len(resp.InitialTimes) {
+		return false
+	}
+	t.markState(types.StateQueued, resp.MsgIDs...)
+
+	// A persistent queue redelivers the requests itself, after its visibility timeout.
+	if t.cfg.SQS.QueueURL != "" {
+		t.recordStatus(ctx, resp, store.StatusQueued)
+		return true
+	}
+
+	go func() {
+		time.Sleep(delay)
+		for i, msgID := range resp.MsgIDs {
+			if rec, err := t.store.Get(ctx, msgID); err == nil && rec != nil {
+				t.requeue(ctx, rec)
+				continue
+			}
+			txReq := types.RestoreRequest(msgs[i], msgID, resp.InitialTimes[i])
+			txReq.MaxGasFeeCap = resp.MaxGasFeeCap
+			if _, err := t.requests.Push(txReq); err != nil {
+				t.logger.Error("failed to requeue tx request", "msg", msgID, "err", err)
+			}
+		}
+	}()
+	return true
+}
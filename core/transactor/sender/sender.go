@@ -2,6 +2,7 @@ package sender
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/berachain/offchain-sdk/client/eth"
@@ -36,15 +37,22 @@ func (s *Sender) Setup(chain eth.Client, logger log.Logger) {
 }
 
 // SendTransaction sends a transaction using the Ethereum client. If the transaction fails to send,
-// it retries based on the configured retry policy.
-func (s *Sender) SendTransaction(ctx context.Context, tx *coretypes.Transaction) error {
-	return s.retryTxWithPolicy(ctx, tx)
+// it retries based on the configured retry policy. It returns the transaction that was sent last,
+// which is a replacement of the given one if it had to be replaced, even if sending failed. The
+// replacements may not exceed the given max gas fee cap (if any).
+func (s *Sender) SendTransaction(
+	ctx context.Context, tx *coretypes.Transaction, maxGasFeeCap *big.Int,
+) (*coretypes.Transaction, error) {
+	return s.retryTxWithPolicy(ctx, tx, maxGasFeeCap)
 }
 
 // retryTxWithPolicy (re)tries sending tx according to the retry policy. Specifically handles two
 // common errors on sending a transaction (NonceTooLow, ReplaceUnderpriced) by replacing the tx
-// appropriately.
-func (s *Sender) retryTxWithPolicy(ctx context.Context, tx *coretypes.Transaction) error {
+// appropriately, as long as the replacement does not exceed the max gas fee cap. It returns the
+// last tx that was sent.
+func (s *Sender) retryTxWithPolicy(
+	ctx context.Context, tx *coretypes.Transaction, maxGasFeeCap *big.Int,
+) (*coretypes.Transaction, error) {
 	for {
 		// (Re)try sending the transaction.
 		err := s.chain.SendTransaction(ctx, tx)
@@ -52,7 +60,7 @@ func (s *Sender) retryTxWithPolicy(ctx context.Context, tx *coretypes.Transactio
 		// Check the policy to see if we should retry this transaction.
 		retry, backoff := s.retryPolicy.Get(tx, err)
 		if !retry {
-			return err
+			return tx, err
 		}
 		time.Sleep(backoff) // Retry after recommended backoff.
 
@@ -61,12 +69,21 @@ func (s *Sender) retryTxWithPolicy(ctx context.Context, tx *coretypes.Transactio
 		s.logger.Error("failed to send tx, retrying...", "hash", currTx, "err", err)
 
 		// Get the replacement tx if necessary.
+		sent := tx
 		if tx, err = s.txReplacementPolicy.GetNew(tx, err); err != nil {
 			s.logger.Error("failed to get replacement tx", "err", err)
-			return err
+			return sent, err
 		}
 
-		// Update the retry policy if the transaction has been changed and log.
+		// Use the factory to build and sign the new transaction.
+		if tx, err = s.factory.RebuildCappedTransactionFromRequest(
+			ctx, maxGasFeeCap, types.CallMsgFromTx(tx), tx.Nonce(),
+		); err != nil {
+			s.logger.Error("failed to build replacement transaction", "err", err)
+			return sent, err
+		}
+
+		// Update the retry policy if the (signed) transaction has been changed and log.
 		if newTx := tx.Hash(); newTx != currTx {
 			s.logger.Debug(
 				"retrying with diff gas and/or nonce",
@@ -75,13 +92,5 @@ func (s *Sender) retryTxWithPolicy(ctx context.Context, tx *coretypes.Transactio
 			)
 			s.retryPolicy.UpdateTxModified(currTx, newTx)
 		}
-
-		// Use the factory to build and sign the new transaction.
-		if tx, err = s.factory.RebuildTransactionFromRequest(
-			ctx, types.CallMsgFromTx(tx), tx.Nonce(),
-		); err != nil {
-			s.logger.Error("failed to build replacement transaction", "err", err)
-			return err
-		}
 	}
 }
@@ -2,6 +2,7 @@ package sender
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -12,8 +13,8 @@ import (
 type (
 	// Factory is an interface for building transactions, used if retrying.
 	Factory interface {
-		RebuildTransactionFromRequest(
-			context.Context, *ethereum.CallMsg, uint64,
+		RebuildCappedTransactionFromRequest(
+			context.Context, *big.Int, *ethereum.CallMsg, uint64,
 		) (*coretypes.Transaction, error)
 	}
 
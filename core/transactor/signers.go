@@ -0,0 +1,285 @@
+package transactor
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/core/transactor/event"
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
+	"github.com/berachain/offchain-sdk/core/transactor/sender"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+	"github.com/berachain/offchain-sdk/log"
+	kmstypes "github.com/berachain/offchain-sdk/types/kms/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// Requests are dispatched to the available signers in turn.
+	SignerRoundRobin = "roundRobin"
+	// Requests are dispatched to the available signer with the fewest txs in flight.
+	SignerLeastInFlight = "leastInFlight"
+	// Requests with the same key are always dispatched to the same available signer, so that
+	// they are sent in order.
+	SignerSticky = "sticky"
+)
+
+// signer sends txs from a single signer, with its own nonce sequence.
+type signer struct {
+	addr     common.Address
+	factory  *factory.Factory
+	noncer   *tracker.Noncer
+	sender   *sender.Sender
+	senderMu sync.Mutex
+	tracker  *tracker.Tracker
+
+	tracked    atomic.Int64 // the number of sent txs that are still tracked for their status
+	draining   atomic.Bool  // whether no new requests are dispatched to the signer
+	lowBalance atomic.Bool  // whether the signer's balance is below the configured minimum
+
+	// fires are the fires of the batches dispatched to the signer, run in order by its worker with
+	// the context of the transactor's current setup.
+	fires chan func(context.Context)
+}
+
+// dispatchQueueSize is the number of batches that may wait to be fired by a signer, before the
+// dispatch of new ones blocks.
+const dispatchQueueSize = 64
+
+// newSigner builds the transactor components of the given signer.
+func newSigner(
+	cfg Config, txSigner kmstypes.TxSigner, batcher factory.Batcher,
+	dispatcher *event.Dispatcher[*tracker.Response],
+) *signer {
+	noncer := tracker.NewNoncer(txSigner.Address(), cfg.PendingNonceInterval)
+	factory := factory.New(
+		noncer, batcher, txSigner, cfg.SignTxTimeout, cfg.MulticallRequireSuccess,
+	)
+	factory.SetMaxGasFeeCap(cfg.MaxGasFeeCap)
+	return &signer{
+		addr:    txSigner.Address(),
+		factory: factory,
+		noncer:  noncer,
+		sender:  sender.New(factory, noncer),
+		tracker: tracker.New(noncer, dispatcher, txSigner.Address(), cfg.TxWaitingTimeout),
+		fires:   make(chan func(context.Context), dispatchQueueSize),
+	}
+}
+
+// setup sets up the signer's components. The txs tracked before, e.g. before a restart of the
+// transactor, are no longer tracked.
+func (s *signer) setup(chain eth.Client, logger log.Logger) {
+	s.factory.SetClient(chain)
+	s.sender.Setup(chain, logger)
+	s.tracker.SetClient(chain)
+	s.tracked.Store(0)
+}
+
+// fireLoop runs the fires dispatched to the signer one at a time, in order, so that the requests
+// of a sticky key are sent in order. The fires left once the context is done are run by the next
+// fire loop, if any.
+func (s *signer) fireLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fire := <-s.fires:
+			fire(ctx)
+		}
+	}
+}
+
+// dispatch queues the given fire to be run by the signer after the ones dispatched before it.
+func (s *signer) dispatch(ctx context.Context, fire func(context.Context)) {
+	select {
+	case <-ctx.Done():
+	case s.fires <- fire:
+	}
+}
+
+// available returns whether new requests may be dispatched to the signer.
+func (s *signer) available() bool {
+	return !s.draining.Load() && !s.lowBalance.Load()
+}
+
+// inFlight returns the number of txs of the signer that are being sent or are not confirmed yet.
+func (s *signer) inFlight() int {
+	acquired, _ := s.noncer.Stats()
+	return acquired + int(s.tracked.Load())
+}
+
+// signerBatch is a batch of requests dispatched to a signer.
+type signerBatch struct {
+	signer   *signer
+	requests types.Requests
+}
+
+// signerPool dispatches requests across a pool of signers, according to the selection strategy.
+type signerPool struct {
+	signers   []*signer
+	selection string
+	next      atomic.Uint64 // the next signer for round-robin selection
+}
+
+// newSignerPool creates a new pool of the given signers, validating the selection strategy.
+func newSignerPool(signers []*signer, selection string) (*signerPool, error) {
+	switch selection {
+	case "":
+		selection = SignerRoundRobin
+	case SignerRoundRobin, SignerLeastInFlight, SignerSticky:
+	default:
+		return nil, fmt.Errorf("unknown signer selection %q", selection)
+	}
+	return &signerPool{signers: signers, selection: selection}, nil
+}
+
+// get returns the signer with the given address, or nil if it is not in the pool.
+func (p *signerPool) get(addr common.Address) *signer {
+	for _, s := range p.signers {
+		if s.addr == addr {
+			return s
+		}
+	}
+	return nil
+}
+
+// available returns the signers that new requests may be dispatched to.
+func (p *signerPool) available() []*signer {
+	var available []*signer
+	for _, s := range p.signers {
+		if s.available() {
+			available = append(available, s)
+		}
+	}
+	return available
+}
+
+// pick returns the signer to dispatch a request with the given sticky key to, or nil if none is
+// available.
+func (p *signerPool) pick(key string) *signer {
+	available := p.available()
+	if len(available) == 0 {
+		return nil
+	}
+
+	switch p.selection {
+	case SignerLeastInFlight:
+		return slices.MinFunc(available, func(a, b *signer) int {
+			return a.inFlight() - b.inFlight()
+		})
+	case SignerSticky:
+		// Rendezvous hashing, so that only the keys of a signer that becomes unavailable move.
+		return slices.MaxFunc(available, func(a, b *signer) int {
+			return cmp.Compare(rendezvousHash(key, a.addr), rendezvousHash(key, b.addr))
+		})
+	default:
+		return available[(p.next.Add(1)-1)%uint64(len(available))]
+	}
+}
+
+// prefer returns the signer with the given address if it is available, so that requests that were
+// already dispatched to it stay in order, or else picks another signer.
+func (p *signerPool) prefer(addr common.Address) *signer {
+	if s := p.get(addr); s != nil && s.available() {
+		return s
+	}
+	return p.pick(addr.Hex())
+}
+
+// assign assigns the given requests to signers. All requests are assigned to the same signer,
+// unless the selection is sticky, in which case they are grouped by the signer of their key.
+func (p *signerPool) assign(requests types.Requests) []*signerBatch {
+	if p.selection != SignerSticky {
+		return []*signerBatch{{signer: p.pick(""), requests: requests}}
+	}
+
+	var batches []*signerBatch
+	for _, req := range requests {
+		s := p.pick(req.StickyKey())
+		i := slices.IndexFunc(batches, func(b *signerBatch) bool { return b.signer == s })
+		if i < 0 {
+			batches = append(batches, &signerBatch{signer: s})
+			i = len(batches) - 1
+		}
+		batches[i].requests = append(batches[i].requests, req)
+	}
+	return batches
+}
+
+// checkBalances marks the signers whose balance is below the given minimum as unavailable, and
+// the others as available again.
+func (p *signerPool) checkBalances(
+	ctx context.Context, chain eth.Client, minBalance *big.Int, logger log.Logger,
+) {
+	if minBalance == nil {
+		return
+	}
+	for _, s := range p.signers {
+		balance, err := chain.BalanceAt(ctx, s.addr, nil)
+		if err != nil {
+			logger.Error("failed to get signer balance", "signer", s.addr, "err", err)
+			continue
+		}
+		lowBalance := balance.Cmp(minBalance) < 0
+		if lowBalance && !s.lowBalance.Load() {
+			logger.Warn("💸 signer balance is low", "signer", s.addr, "balance", balance)
+		}
+		s.lowBalance.Store(lowBalance)
+	}
+}
+
+// rendezvousHash returns the weight of the given signer for the given key.
+func rendezvousHash(key string, addr common.Address) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write(addr.Bytes())
+	return h.Sum64()
+}
+
+// Signers returns the addresses of the transactor's signers.
+func (t *TxrV2) Signers() []common.Address {
+	addrs := make([]common.Address, len(t.signers.signers))
+	for i, s := range t.signers.signers {
+		addrs[i] = s.addr
+	}
+	return addrs
+}
+
+// DrainSigner stops dispatching new requests to the given signer, e.g. to retire or refill it.
+// Its txs that are already sent are still tracked (and resent with bumped gas if stuck).
+func (t *TxrV2) DrainSigner(addr common.Address) error {
+	s := t.signers.get(addr)
+	if s == nil {
+		return fmt.Errorf("unknown signer %s", addr)
+	}
+	s.draining.Store(true)
+	return nil
+}
+
+// ResumeSigner resumes dispatching new requests to the given drained signer.
+func (t *TxrV2) ResumeSigner(addr common.Address) error {
+	s := t.signers.get(addr)
+	if s == nil {
+		return fmt.Errorf("unknown signer %s", addr)
+	}
+	s.draining.Store(false)
+	return nil
+}
+
+// Drained returns whether the given signer is draining and has no more txs being sent or not
+// confirmed yet.
+func (t *TxrV2) Drained(addr common.Address) (bool, error) {
+	s := t.signers.get(addr)
+	if s == nil {
+		return false, fmt.Errorf("unknown signer %s", addr)
+	}
+	return s.draining.Load() && s.inFlight() == 0, nil
+}
@@ -0,0 +1,198 @@
+package transactor
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSigners returns the given number of signers, with no txs in flight.
+func newTestSigners(n int) []*signer {
+	signers := make([]*signer, n)
+	for i := range signers {
+		addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+		signers[i] = &signer{addr: addr, noncer: tracker.NewNoncer(addr, time.Minute)}
+	}
+	return signers
+}
+
+// newTestRequest returns a tx request with the given message ID and sticky key.
+func newTestRequest(msgID, key string) *types.Request {
+	req := types.RestoreRequest(&ethereum.CallMsg{}, msgID, time.Now())
+	req.Key = key
+	return req
+}
+
+func TestSignerPoolPick(t *testing.T) {
+	signers := newTestSigners(3)
+	signers[0].tracked.Store(2)
+	signers[1].tracked.Store(1)
+	signers[2].tracked.Store(3)
+
+	for _, tc := range []struct {
+		name      string
+		selection string
+		expected  []*signer
+	}{
+		{
+			name:      "round robin",
+			selection: SignerRoundRobin,
+			expected:  []*signer{signers[0], signers[1], signers[2], signers[0]},
+		},
+		{
+			name:      "default",
+			selection: "",
+			expected:  []*signer{signers[0], signers[1], signers[2], signers[0]},
+		},
+		{
+			name:      "least in flight",
+			selection: SignerLeastInFlight,
+			expected:  []*signer{signers[1], signers[1], signers[1], signers[1]},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pool, err := newSignerPool(signers, tc.selection)
+			require.NoError(t, err)
+			for _, expected := range tc.expected {
+				require.Equal(t, expected, pool.pick(""))
+			}
+		})
+	}
+
+	_, err := newSignerPool(signers, "random")
+	require.Error(t, err)
+}
+
+func TestSignerPoolStickyStability(t *testing.T) {
+	signers := newTestSigners(4)
+	pool, err := newSignerPool(signers, SignerSticky)
+	require.NoError(t, err)
+
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	picked := make(map[string]*signer)
+	for _, key := range keys {
+		picked[key] = pool.pick(key)
+		require.Equal(t, picked[key], pool.pick(key), "the same key picks the same signer")
+	}
+
+	// Only the keys of a signer that becomes unavailable move, to the other signers.
+	signers[0].draining.Store(true)
+	for _, key := range keys {
+		s := pool.pick(key)
+		require.NotEqual(t, signers[0], s)
+		if picked[key] != signers[0] {
+			require.Equal(t, picked[key], s)
+		}
+	}
+
+	// Once available again, the keys move back.
+	signers[0].draining.Store(false)
+	for _, key := range keys {
+		require.Equal(t, picked[key], pool.pick(key))
+	}
+}
+
+func TestSignerPoolAssign(t *testing.T) {
+	signers := newTestSigners(2)
+	requests := types.Requests{
+		newTestRequest("1", "a"), newTestRequest("2", "b"), newTestRequest("3", "a"),
+	}
+
+	for _, tc := range []struct {
+		name      string
+		selection string
+		batches   int
+	}{
+		{name: "round robin", selection: SignerRoundRobin, batches: 1},
+		{name: "least in flight", selection: SignerLeastInFlight, batches: 1},
+		{name: "sticky", selection: SignerSticky},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pool, err := newSignerPool(signers, tc.selection)
+			require.NoError(t, err)
+			batches := pool.assign(requests)
+
+			var assigned []string
+			for _, batch := range batches {
+				require.NotNil(t, batch.signer)
+				assigned = append(assigned, batch.requests.MsgIDs()...)
+				if tc.selection == SignerSticky {
+					// The requests of each batch are the ones whose key picks its signer, in order.
+					for _, req := range batch.requests {
+						require.Equal(t, batch.signer, pool.pick(req.StickyKey()))
+					}
+				}
+			}
+			require.ElementsMatch(t, []string{"1", "2", "3"}, assigned)
+			if tc.batches > 0 {
+				require.Len(t, batches, tc.batches)
+			}
+		})
+	}
+
+	// With no signer available, the requests are assigned to none.
+	signers[0].draining.Store(true)
+	signers[1].lowBalance.Store(true)
+	pool, err := newSignerPool(signers, SignerSticky)
+	require.NoError(t, err)
+	batches := pool.assign(requests)
+	require.Len(t, batches, 1)
+	require.Nil(t, batches[0].signer)
+}
+
+func TestSignerAvailability(t *testing.T) {
+	signers := newTestSigners(2)
+	pool, err := newSignerPool(signers, SignerRoundRobin)
+	require.NoError(t, err)
+	txr := &TxrV2{signers: pool}
+
+	// A drained signer is no longer picked, and is drained once it has no txs in flight.
+	require.NoError(t, txr.DrainSigner(signers[0].addr))
+	require.Equal(t, []*signer{signers[1]}, pool.available())
+	signers[0].tracked.Store(1)
+	drained, err := txr.Drained(signers[0].addr)
+	require.NoError(t, err)
+	require.False(t, drained)
+	signers[0].tracked.Store(0)
+	drained, err = txr.Drained(signers[0].addr)
+	require.NoError(t, err)
+	require.True(t, drained)
+	require.NoError(t, txr.ResumeSigner(signers[0].addr))
+	require.Len(t, pool.available(), 2)
+
+	unknown := common.HexToAddress("0xdead")
+	require.Error(t, txr.DrainSigner(unknown))
+	require.Error(t, txr.ResumeSigner(unknown))
+	_, err = txr.Drained(unknown)
+	require.Error(t, err)
+
+	// A signer whose balance drops below the minimum is no longer picked, until it is refilled.
+	balances := map[common.Address]int64{signers[0].addr: 5, signers[1].addr: 20}
+	client := new(mocks.Client)
+	client.On("BalanceAt", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, addr common.Address, _ *big.Int) (*big.Int, error) {
+			return big.NewInt(balances[addr]), nil
+		},
+	)
+	logger := log.NewLogger(os.Stdout, "test")
+	pool.checkBalances(context.Background(), client, big.NewInt(10), logger)
+	require.Equal(t, []*signer{signers[1]}, pool.available())
+
+	balances[signers[0].addr] = 10
+	pool.checkBalances(context.Background(), client, big.NewInt(10), logger)
+	require.Len(t, pool.available(), 2)
+
+	// Without a minimum balance, balances are not checked.
+	pool.checkBalances(context.Background(), nil, nil, logger)
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// recordPrefix prefixes the keys of the records in the DB.
+const recordPrefix = "transactor/request/"
+
+// KVStore persists the records of tx requests in a key-value store, e.g. the app's DB.
+type KVStore struct {
+	db ethdb.KeyValueStore
+}
+
+// NewKVStore creates a new store on top of the given DB.
+func NewKVStore(db ethdb.KeyValueStore) *KVStore {
+	return &KVStore{db: db}
+}
+
+// Get implements Store.
+func (s *KVStore) Get(_ context.Context, msgID string) (*Record, error) {
+	key := []byte(recordPrefix + msgID)
+	if has, err := s.db.Has(key); err != nil || !has {
+		return nil, err
+	}
+
+	bz, err := s.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	rec := new(Record)
+	if err = json.Unmarshal(bz, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Put implements Store.
+func (s *KVStore) Put(_ context.Context, rec *Record) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.db.Put([]byte(recordPrefix+rec.MsgID), bz)
+}
+
+// Delete implements Store.
+func (s *KVStore) Delete(_ context.Context, msgID string) error {
+	return s.db.Delete([]byte(recordPrefix + msgID))
+}
+
+// Pending implements Store.
+func (s *KVStore) Pending(_ context.Context) ([]*Record, error) {
+	it := s.db.NewIterator([]byte(recordPrefix), nil)
+	defer it.Release()
+
+	var recs []*Record
+	for it.Next() {
+		rec := new(Record)
+		if err := json.Unmarshal(it.Value(), rec); err != nil {
+			return nil, err
+		}
+		if !rec.Status.Done() {
+			recs = append(recs, rec)
+		}
+	}
+	return recs, it.Error()
+}
+
+// Prune implements Store.
+func (s *KVStore) Prune(_ context.Context, before time.Time) error {
+	it := s.db.NewIterator([]byte(recordPrefix), nil)
+	defer it.Release()
+
+	batch := s.db.NewBatch()
+	for it.Next() {
+		rec := new(Record)
+		if err := json.Unmarshal(it.Value(), rec); err != nil {
+			return err
+		}
+		if rec.Status.Done() && rec.UpdatedAt.Before(before) {
+			if err := batch.Delete(it.Key()); err != nil {
+				return err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVStore(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewKVStore(memorydb.New())
+
+	// No record is persisted yet.
+	rec, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Nil(t, rec)
+
+	require.NoError(t, s.Put(ctx, &store.Record{MsgID: "a", Status: store.StatusQueued}))
+	require.NoError(t, s.Put(ctx, &store.Record{
+		MsgID: "b", Status: store.StatusMined, Nonce: 1, TxHashes: []common.Hash{{1}, {2}},
+	}))
+
+	rec, err = s.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, store.StatusMined, rec.Status)
+	require.Equal(t, common.Hash{2}, rec.LatestTxHash())
+
+	// Only the records whose status is not final are pending.
+	recs, err := s.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	require.Equal(t, "a", recs[0].MsgID)
+
+	require.NoError(t, s.Delete(ctx, "a"))
+	recs, err = s.Pending(ctx)
+	require.NoError(t, err)
+	require.Empty(t, recs)
+}
+
+func TestKVStorePrune(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewKVStore(memorydb.New())
+	now := time.Now()
+
+	require.NoError(t, s.Put(ctx, &store.Record{
+		MsgID: "old", Status: store.StatusReverted, UpdatedAt: now.Add(-2 * time.Hour),
+		History: []store.Event{
+			{Status: store.StatusQueued}, {Status: store.StatusReverted, TxHash: common.Hash{1}},
+		},
+	}))
+	require.NoError(t, s.Put(ctx, &store.Record{
+		MsgID: "new", Status: store.StatusMined, UpdatedAt: now,
+	}))
+	require.NoError(t, s.Put(ctx, &store.Record{
+		MsgID: "pending", Status: store.StatusInFlight, UpdatedAt: now.Add(-2 * time.Hour),
+	}))
+
+	rec, err := s.Get(ctx, "old")
+	require.NoError(t, err)
+	require.Len(t, rec.History, 2)
+	require.Equal(t, common.Hash{1}, rec.History[1].TxHash)
+
+	// Only the records that are done and last updated before the given time are pruned.
+	require.NoError(t, s.Prune(ctx, now.Add(-time.Hour)))
+	for msgID, kept := range map[string]bool{"old": false, "new": true, "pending": true} {
+		rec, err = s.Get(ctx, msgID)
+		require.NoError(t, err)
+		require.Equal(t, kept, rec != nil, msgID)
+	}
+}
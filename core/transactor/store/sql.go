@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SQLStore persists the records of tx requests in a SQL database, in the "transactor_requests"
+// table. The database must support "$n" placeholders and upserts with "ON CONFLICT", like
+// Postgres and SQLite.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates a new store on top of the given database, creating its table if needed.
+func NewSQLStore(ctx context.Context, db *sql.DB) (*SQLStore, error) {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS transactor_requests (
+		msg_id TEXT PRIMARY KEY,
+		done BOOLEAN NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		record TEXT NOT NULL
+	)`); err != nil {
+		return nil, err
+	}
+	return &SQLStore{db: db}, nil
+}
+
+// Get implements Store.
+func (s *SQLStore) Get(ctx context.Context, msgID string) (*Record, error) {
+	var bz string
+	err := s.db.QueryRowContext(
+		ctx, "SELECT record FROM transactor_requests WHERE msg_id = $1", msgID,
+	).Scan(&bz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil //nolint:nilnil // no record is not an error.
+	} else if err != nil {
+		return nil, err
+	}
+
+	rec := new(Record)
+	if err = json.Unmarshal([]byte(bz), rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Put implements Store.
+func (s *SQLStore) Put(ctx context.Context, rec *Record) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO transactor_requests
+		(msg_id, done, updated_at, record) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (msg_id) DO UPDATE SET
+		done = excluded.done, updated_at = excluded.updated_at, record = excluded.record`,
+		rec.MsgID, rec.Status.Done(), rec.UpdatedAt, string(bz),
+	)
+	return err
+}
+
+// Delete implements Store.
+func (s *SQLStore) Delete(ctx context.Context, msgID string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM transactor_requests WHERE msg_id = $1", msgID)
+	return err
+}
+
+// Pending implements Store.
+func (s *SQLStore) Pending(ctx context.Context) ([]*Record, error) {
+	rows, err := s.db.QueryContext(
+		ctx, "SELECT record FROM transactor_requests WHERE done = $1", false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recs []*Record
+	for rows.Next() {
+		var bz string
+		if err = rows.Scan(&bz); err != nil {
+			return nil, err
+		}
+		rec := new(Record)
+		if err = json.Unmarshal([]byte(bz), rec); err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, rows.Err()
+}
+
+// Prune implements Store.
+func (s *SQLStore) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.db.ExecContext(
+		ctx, "DELETE FROM transactor_requests WHERE done = $1 AND updated_at < $2", true, before,
+	)
+	return err
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Status is the status of a tx request in its lifecycle.
+type Status string
+
+const (
+	// The request is sitting in the queue, waiting to be acquired into a tx.
+	StatusQueued Status = "queued"
+	// The request is being built into a tx.
+	StatusBuilding Status = "building"
+	// The tx containing the request is sending (or retrying).
+	StatusSending Status = "sending"
+	// The tx containing the request has been sent, and is waiting to be confirmed.
+	StatusInFlight Status = "inFlight"
+	// The tx containing the request was included in a block successfully.
+	StatusMined Status = "mined"
+	// The tx containing the request was included in a block, but reverted.
+	StatusReverted Status = "reverted"
+	// The request failed to be built or sent, or its tx went stale without being resent.
+	StatusFailed Status = "failed"
+)
+
+// Done returns whether the status is final.
+func (s Status) Done() bool {
+	return s == StatusMined || s == StatusReverted || s == StatusFailed
+}
+
+// Event is a status change in the lifecycle of a tx request.
+type Event struct {
+	Status Status `json:"status"`
+	// TxHash is the hash of the tx containing the request at the time, if it was built.
+	TxHash common.Hash `json:"txHash"`
+	// Error is the error that the request failed with, if any.
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// Record is the persisted lifecycle of a tx request.
+type Record struct {
+	MsgID  string `json:"msgID"`
+	Status Status `json:"status"`
+	// History are all the status changes of the request, in order.
+	History []Event `json:"history,omitempty"`
+
+	// Request is the call msg of the request, to rebuild its tx if needed.
+	Request *ethereum.CallMsg `json:"request,omitempty"`
+	// Key is the sticky key of the request, if any.
+	Key string `json:"key,omitempty"`
+	// MaxGasFeeCap is the max gas fee cap of the request, if any.
+	MaxGasFeeCap *big.Int `json:"maxGasFeeCap,omitempty"`
+
+	// Signer is the signer of the request's latest tx, if it was built.
+	Signer common.Address `json:"signer"`
+	// Nonce is the nonce of the request's latest tx, if it was built.
+	Nonce uint64 `json:"nonce"`
+	// TxHashes are the hashes of all the txs that the request was sent with, e.g. replacements
+	// with bumped gas, in order.
+	TxHashes []common.Hash `json:"txHashes,omitempty"`
+	// Error is the error that the request failed with, if any.
+	Error string `json:"error,omitempty"`
+
+	// Receipt is the receipt of the request's tx, once it was mined or reverted.
+	Receipt *coretypes.Receipt `json:"receipt,omitempty"`
+	// RevertReason is the decoded reason that the request's tx reverted with, if known.
+	RevertReason string `json:"revertReason,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// LatestTxHash returns the hash of the request's latest tx, or the empty hash if it was not built.
+func (r *Record) LatestTxHash() common.Hash {
+	if len(r.TxHashes) == 0 {
+		return common.Hash{}
+	}
+	return r.TxHashes[len(r.TxHashes)-1]
+}
+
+// Store persists the records of tx requests, so that the transactor can reconcile them against
+// the chain after a restart and report their status.
+type Store interface {
+	// Get returns the record of the request with the given message ID, or nil if none.
+	Get(ctx context.Context, msgID string) (*Record, error)
+	// Put creates or updates the record of a request.
+	Put(ctx context.Context, rec *Record) error
+	// Delete deletes the record of the request with the given message ID.
+	Delete(ctx context.Context, msgID string) error
+	// Pending returns the records of all requests whose status is not final.
+	Pending(ctx context.Context) ([]*Record, error)
+	// Prune deletes the records of all requests whose status is final and that were last updated
+	// before the given time.
+	Prune(ctx context.Context, before time.Time) error
+}
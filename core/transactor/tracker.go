@@ -2,9 +2,12 @@ package transactor
 
 import (
 	"context"
+	"errors"
 	"sync"
 
+	"github.com/berachain/offchain-sdk/core/transactor/factory"
 	"github.com/berachain/offchain-sdk/core/transactor/sender"
+	"github.com/berachain/offchain-sdk/core/transactor/store"
 	"github.com/berachain/offchain-sdk/core/transactor/tracker"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 
@@ -12,9 +15,13 @@ import (
 )
 
 // OnError is called when a transaction request fails to build or send.
-func (t *TxrV2) OnError(_ context.Context, resp *tracker.Response) {
-	t.noncer.RemoveAcquired(resp.Nonce())
+func (t *TxrV2) OnError(ctx context.Context, resp *tracker.Response) {
+	if s := t.signers.get(resp.Signer); s != nil {
+		s.noncer.RemoveAcquired(resp.Nonce())
+	}
 	t.removeStateTracking(resp.MsgIDs...)
+	t.recordStatus(ctx, resp, store.StatusFailed)
+	t.complete(ctx, resp, nil, "")
 	t.logger.Error("❌ error sending transaction", "err", resp.Error, "msgs", resp.MsgIDs)
 
 	// TODO: move ontop dead queue, for SQS.
@@ -22,7 +29,10 @@ func (t *TxrV2) OnError(_ context.Context, resp *tracker.Response) {
 
 // OnSuccess is called when a transaction has been successfully included in a block.
 func (t *TxrV2) OnSuccess(resp *tracker.Response, receipt *coretypes.Receipt) {
+	t.untrack(resp)
 	t.removeStateTracking(resp.MsgIDs...)
+	t.recordOutcome(context.Background(), resp, receipt, "")
+	t.complete(context.Background(), resp, receipt, "")
 	t.logger.Info(
 		"⛏️ transaction mined: success", "tx-hash", receipt.TxHash.Hex(),
 		"gas-used", receipt.GasUsed, "status", receipt.Status, "nonce", resp.Nonce(),
@@ -51,10 +61,15 @@ func (t *TxrV2) OnSuccess(resp *tracker.Response, receipt *coretypes.Receipt) {
 
 // OnRevert is called when a transaction has been reverted.
 func (t *TxrV2) OnRevert(resp *tracker.Response, receipt *coretypes.Receipt) {
+	t.untrack(resp)
 	t.removeStateTracking(resp.MsgIDs...)
+	reason := t.revertReason(context.Background(), resp.Transaction, receipt)
+	t.recordOutcome(context.Background(), resp, receipt, reason)
+	t.complete(context.Background(), resp, receipt, reason)
 	t.logger.Warn(
 		"🔻 transaction mined: reverted", "tx-hash", receipt.TxHash.Hex(),
 		"gas-used", receipt.GasUsed, "status", receipt.Status, "nonce", resp.Nonce(),
+		"reason", reason,
 	)
 
 	// TODO: delete from SQS queue / move onto the dead queue?
@@ -62,6 +77,7 @@ func (t *TxrV2) OnRevert(resp *tracker.Response, receipt *coretypes.Receipt) {
 
 // OnStale is called when a transaction becomes stale after the configured timeout.
 func (t *TxrV2) OnStale(ctx context.Context, resp *tracker.Response, isPending bool) {
+	t.untrack(resp)
 	t.removeStateTracking(resp.MsgIDs...)
 	t.logger.Warn(
 		"🔄 transaction is stale", "tx-hash", resp.Hash(),
@@ -71,11 +87,53 @@ func (t *TxrV2) OnStale(ctx context.Context, resp *tracker.Response, isPending b
 	if isPending {
 		// For a tx that gets stuck in the mempool as pending, it can only be included in a block
 		// by bumping gas. Resend it (same tx data, same nonce) with a bumped gas.
-		resp.Transaction = sender.BumpGas(resp.Transaction)
-		go t.fire(ctx, resp, false)
+		go t.bump(ctx, t.signers.get(resp.Signer), resp)
 	} else if t.cfg.ResendStaleTxs {
 		// Try resending the tx to the chain if configured to do so. Rebuild it (same tx data, new
-		// nonce) and resend.
-		go t.fire(ctx, resp, true, types.CallMsgFromTx(resp.Transaction))
+		// nonce) and resend, preferably from the same signer.
+		go t.fire(
+			ctx, t.signers.prefer(resp.Signer), resp, true, types.CallMsgFromTx(resp.Transaction),
+		)
+	} else {
+		resp.Error = errors.New("tx is stale")
+		t.recordStatus(ctx, resp, store.StatusFailed)
+		t.complete(ctx, resp, nil, "")
+	}
+}
+
+// bump rebuilds the stuck tx of the given response with a bumped gas at its nonce, and resends it
+// from its signer. If the bumped gas fees would exceed the max gas fee cap, the tx is tracked
+// again as is under either max gas fee policy, since it is still pending and may be included.
+func (t *TxrV2) bump(ctx context.Context, s *signer, resp *tracker.Response) {
+	if s == nil {
+		resp.Error = errNoSigner
+		t.dispatcher.Dispatch(resp)
+		return
+	}
+
+	tx, err := s.factory.RebuildCappedTransactionFromRequest(
+		ctx, resp.MaxGasFeeCap, types.CallMsgFromTx(sender.BumpGas(resp.Transaction)),
+		resp.Nonce(),
+	)
+	switch {
+	case errors.Is(err, factory.ErrMaxGasFeeCapExceeded):
+		t.logger.Warn(
+			"⛽ bumped gas fees exceed the max, tracking the stale tx as is", "err", err,
+			"tx-hash", resp.Hash(),
+		)
+		t.track(ctx, s, resp)
+	case err != nil:
+		resp.Error = err
+		t.dispatcher.Dispatch(resp)
+	default:
+		resp.Transaction = tx
+		t.fire(ctx, s, resp, false)
+	}
+}
+
+// untrack marks the tx of the given response as no longer tracked for its signer.
+func (t *TxrV2) untrack(resp *tracker.Response) {
+	if s := t.signers.get(resp.Signer); s != nil {
+		s.tracked.Add(-1)
 	}
 }
@@ -46,8 +46,16 @@ func NewNoncer(sender common.Address, refreshInterval time.Duration) *Noncer {
 }
 
 func (n *Noncer) Start(ctx context.Context, ethClient eth.Client) {
+	go n.Run(ctx, ethClient)
+}
+
+// Run refreshes the nonces from the given client periodically, until the context is done. It is
+// blocking, see Start.
+func (n *Noncer) Run(ctx context.Context, ethClient eth.Client) {
+	n.mu.Lock()
 	n.ethClient = ethClient
-	go n.refreshLoop(ctx)
+	n.mu.Unlock()
+	n.refreshLoop(ctx)
 }
 
 func (n *Noncer) refreshLoop(ctx context.Context) {
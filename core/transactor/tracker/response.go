@@ -1,6 +1,7 @@
 package tracker
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,9 +12,11 @@ import (
 type Response struct {
 	*coretypes.Transaction
 
-	MsgIDs       []string    // Message IDs that were included in the transaction.
-	InitialTimes []time.Time // Times each message was initially fired.
-	Error        error       // Build or send error.
+	Signer       common.Address // Address of the signer that the transaction is sent from.
+	MsgIDs       []string       // Message IDs that were included in the transaction.
+	InitialTimes []time.Time    // Times each message was initially fired.
+	Error        error          // Build or send error.
+	MaxGasFeeCap *big.Int       // Max gas fee cap that the transaction may be built with, if any.
 
 	// fields only the tracker will set
 	receipt *coretypes.Receipt
@@ -0,0 +1,49 @@
+package transactor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBumpExceedingMaxGasFeeCap tests that a stale tx that is still pending, and whose bumped gas
+// fees would exceed the max gas fee cap, is tracked again as is under either max gas fee policy.
+func TestBumpExceedingMaxGasFeeCap(t *testing.T) {
+	for _, policy := range []string{MaxGasFeeReject, MaxGasFeeQueue} {
+		t.Run(policy, func(t *testing.T) {
+			txr, err := NewTransactor(Config{
+				EmptyQueueDelay: time.Millisecond, PendingNonceInterval: time.Second,
+				MaxGasFeeCap: big.NewInt(100), MaxGasFeePolicy: policy,
+			}, newTestSigner(t), nil)
+			require.NoError(t, err)
+			sCtx := newTestContext(newTestChain())
+			require.NoError(t, txr.Setup(sCtx))
+			defer func() { require.NoError(t, txr.Teardown(sCtx)) }()
+			s := txr.signers.signers[0]
+
+			to := common.HexToAddress("0x1")
+			tx := coretypes.NewTx(&coretypes.DynamicFeeTx{
+				ChainID: big.NewInt(1), Nonce: 3, To: &to, Gas: 21000,
+				GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100),
+			})
+			resp := &tracker.Response{Transaction: tx, MsgIDs: []string{"1"}, Signer: s.addr}
+
+			// The status of the tracked tx is not checked once the context is done.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			txr.bump(ctx, s, resp)
+
+			require.NoError(t, resp.Error)
+			require.Equal(t, tx, resp.Transaction)
+			require.Equal(t, int64(1), s.tracked.Load())
+			require.Equal(t, types.StateInFlight, txr.GetPreconfirmedState("1"))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package transactor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/berachain/offchain-sdk/core/transactor/event"
 	"github.com/berachain/offchain-sdk/core/transactor/factory"
 	"github.com/berachain/offchain-sdk/core/transactor/sender"
+	"github.com/berachain/offchain-sdk/core/transactor/store"
 	"github.com/berachain/offchain-sdk/core/transactor/tracker"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 	"github.com/berachain/offchain-sdk/log"
@@ -24,25 +26,51 @@ import (
 
 // TxrV2 is the main transactor object. TODO: deprecate off being a job.
 type TxrV2 struct {
-	cfg        Config
-	logger     log.Logger
-	signerAddr common.Address
+	cfg    Config
+	logger log.Logger
+	chain  eth.Client
 
 	requests     queuetypes.Queue[*types.Request]
-	factory      *factory.Factory
-	noncer       *tracker.Noncer
-	sender       *sender.Sender
-	senderMu     sync.Mutex
+	batcher      factory.Batcher
+	signers      *signerPool
 	dispatcher   *event.Dispatcher[*tracker.Response]
-	tracker      *tracker.Tracker
 	trackerIndex int
 
 	preconfirmedStates map[string]types.PreconfirmedState
 	preconfirmedMu     sync.RWMutex
+
+	store   store.Store
+	storeMu sync.Mutex
+
+	callbacks   map[string][]Callback
+	callbacksMu sync.Mutex
+
+	// cancel cancels the context of the current setup, which all the transactor's loops run with,
+	// and running tracks the loops until they return.
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+
+	// pushMu is read-locked by the pushes of requests whose message ID is only known once pushed,
+	// until they are registered, and write-locked before processing received requests, so that
+	// no request is processed before it is registered.
+	pushMu sync.RWMutex
 }
 
 // NewTransactor creates a new transactor with the given config and signer.
 func NewTransactor(cfg Config, signer kmstypes.TxSigner, batcher factory.Batcher) (*TxrV2, error) {
+	return NewMultiSignerTransactor(cfg, []kmstypes.TxSigner{signer}, batcher)
+}
+
+// NewMultiSignerTransactor creates a new transactor with the given config and pool of signers,
+// each with its own nonce sequence. Requests are dispatched across the signers according to the
+// configured signer selection.
+func NewMultiSignerTransactor(
+	cfg Config, txSigners []kmstypes.TxSigner, batcher factory.Batcher,
+) (*TxrV2, error) {
+	if len(txSigners) == 0 {
+		return nil, errors.New("at least 1 signer must be provided")
+	}
+
 	// Determine queue type based on given configuration.
 	var queue queuetypes.Queue[*types.Request]
 	if cfg.SQS.QueueURL != "" {
@@ -54,67 +82,128 @@ func NewTransactor(cfg Config, signer kmstypes.TxSigner, batcher factory.Batcher
 		queue = mem.NewQueue[*types.Request]()
 	}
 
+	if cfg.StatusRetention == 0 {
+		cfg.StatusRetention = defaultStatusRetention
+	}
+
 	// Ensure a batcher is provided if batching is required.
 	if cfg.TxBatchSize > 1 && batcher == nil {
 		return nil, errors.New("batcher must be provided when tx batch size is greater than 1")
 	}
 
-	// Build the transactor components.
-	noncer := tracker.NewNoncer(signer.Address(), cfg.PendingNonceInterval)
-	factory := factory.New(noncer, batcher, signer, cfg.SignTxTimeout, cfg.MulticallRequireSuccess)
+	// Build the transactor components, for each signer.
 	dispatcher := event.NewDispatcher[*tracker.Response]()
-	tracker := tracker.New(noncer, dispatcher, signer.Address(), cfg.TxWaitingTimeout)
+	signers := make([]*signer, len(txSigners))
+	for i, txSigner := range txSigners {
+		signers[i] = newSigner(cfg, txSigner, batcher, dispatcher)
+	}
+	pool, err := newSignerPool(signers, cfg.SignerSelection)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.MaxGasFeePolicy {
+	case "", MaxGasFeeReject, MaxGasFeeQueue:
+	default:
+		return nil, fmt.Errorf("unknown max gas fee policy %q", cfg.MaxGasFeePolicy)
+	}
 
 	return &TxrV2{
 		cfg:                cfg,
 		requests:           queue,
-		signerAddr:         signer.Address(),
-		factory:            factory,
-		noncer:             noncer,
-		sender:             sender.New(factory, noncer),
+		batcher:            batcher,
+		signers:            pool,
 		dispatcher:         dispatcher,
-		tracker:            tracker,
 		preconfirmedStates: make(map[string]types.PreconfirmedState),
+		callbacks:          make(map[string][]Callback),
 	}, nil
 }
 
+// SetGasPricer sets the gas pricer that suggests the gas fees of the txs of all signers, if not
+// provided by their requests. It must be called before the transactor is set up.
+func (t *TxrV2) SetGasPricer(gasPricer factory.GasPricer) {
+	for _, s := range t.signers.signers {
+		s.factory.SetGasPricer(gasPricer)
+	}
+}
+
 // RegistryKey implements job.Basic.
 func (t *TxrV2) RegistryKey() string {
 	return "transactor"
 }
 
-// Setup implements job.HasSetup.
-func (t *TxrV2) Setup(ctx context.Context) error {
+// Setup implements job.HasSetup. All the loops it starts run until the transactor is torn down,
+// so that it may be set up again, e.g. on every election of a singleton.
+func (t *TxrV2) Setup(ctx context.Context) (err error) {
 	sCtx := sdk.UnwrapContext(ctx)
 	chain := sCtx.Chain()
 	t.logger = sCtx.Logger()
+	t.chain = chain
+
+	runCtx, cancel := context.WithCancel(sCtx)
+	ctx, t.cancel = sCtx.WithContext(runCtx), cancel
+	defer func() {
+		if err != nil {
+			_ = t.Teardown(sCtx)
+		}
+	}()
 
 	// Register the transactor as a subscriber to the tracker.
 	t.trackerIndex = t.SubscribeTxResults(ctx, t)
 
-	// Setup and start all the transactor components.
-	t.factory.SetClient(chain)
-	t.sender.Setup(chain, t.logger)
-	t.tracker.SetClient(chain)
-	t.noncer.Start(ctx, chain)
+	// Setup and start all the transactor components, for each signer.
+	for _, s := range t.signers.signers {
+		s.setup(chain, t.logger)
+		t.run(func() { s.noncer.Run(ctx, chain) })
+		t.run(func() { s.fireLoop(ctx) })
+	}
+	t.signers.checkBalances(ctx, chain, t.cfg.MinSignerBalance, t.logger)
+
+	// Reconcile the persisted requests that were not done before the restart, if any.
+	if err = t.setupStore(ctx, sCtx.DB()); err != nil {
+		return err
+	}
+	pending, err := t.reconcile(ctx, chain)
+	if err != nil {
+		return err
+	}
 
 	// If there are any pending txns at startup, they are likely to be "stuck". Resend them.
-	if err := t.resendStaleTxns(ctx, chain); err != nil {
+	if err = t.resendStaleTxns(ctx, chain, pending); err != nil {
 		return err
 	}
 
-	go t.mainLoop(ctx)
+	t.run(func() { t.mainLoop(ctx) })
 
 	return nil
 }
 
+// run runs the given loop of the current setup in a goroutine, tracked until it returns.
+func (t *TxrV2) run(loop func()) {
+	t.running.Add(1)
+	go func() {
+		defer t.running.Done()
+		loop()
+	}()
+}
+
+// Singleton implements job.Singleton, since only one replica may send txs from the signers.
+func (t *TxrV2) Singleton() bool {
+	return true
+}
+
 // Execute implements job.Basic.
-func (t *TxrV2) Execute(context.Context, any) (any, error) {
-	acquired, inFlight := t.noncer.Stats()
-	t.logger.Info(
-		"🧠 system status",
-		"waiting-tx", acquired, "in-flight-tx", inFlight, "pending-requests", t.requests.Len(),
-	)
+func (t *TxrV2) Execute(ctx context.Context, _ any) (any, error) {
+	t.logger.Info("🧠 system status", "pending-requests", t.requests.Len())
+	for _, s := range t.signers.signers {
+		acquired, inFlight := s.noncer.Stats()
+		t.logger.Info(
+			"🧠 signer status", "signer", s.addr, "waiting-tx", acquired, "in-flight-tx", inFlight,
+			"draining", s.draining.Load(), "low-balance", s.lowBalance.Load(),
+		)
+	}
+	t.signers.checkBalances(ctx, t.chain, t.cfg.MinSignerBalance, t.logger)
+	t.pruneRecords(ctx)
 	return 1, nil
 }
 
@@ -123,10 +212,27 @@ func (t *TxrV2) IntervalTime(context.Context) time.Duration {
 	return t.cfg.StatusUpdateInterval
 }
 
-// Teardown implements job.HasTeardown.
-func (t *TxrV2) Teardown() error {
+// Teardown implements job.HasTeardownContext. It stops all the loops of the current setup, and
+// waits for them to return until the given context is done.
+func (t *TxrV2) Teardown(ctx context.Context) error {
+	if t.cancel == nil {
+		return nil
+	}
 	t.dispatcher.Unsubscribe(t.trackerIndex)
-	return nil
+	t.cancel()
+	t.cancel = nil
+
+	done := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // SubscribeTxResults ensures that tx results, once confirmed, are sent the given subscriber. It
@@ -137,23 +243,65 @@ func (t *TxrV2) SubscribeTxResults(ctx context.Context, subscriber tracker.Subsc
 	return t.dispatcher.Subscribe(ch)
 }
 
-// SendTxRequest adds the given tx request to the tx queue, after validating it.
-func (t *TxrV2) SendTxRequest(txReq *types.Request) (string, error) {
+// SendTxRequest adds the given tx request to the tx queue, after validating it. The given
+// callbacks, if any, are called with the request's result once it is done.
+func (t *TxrV2) SendTxRequest(txReq *types.Request, callbacks ...Callback) (string, error) {
 	if err := txReq.Validate(); err != nil {
 		return "", err
 	}
+	if len(callbacks) > 0 && txReq.MsgID == "" && !t.cfg.UseQueueMessageID {
+		return "", errors.New("tx request must have a message ID to be called back")
+	}
 
-	msgID := txReq.MsgID
+	// Register the request before pushing it, so that its status is not updated and it is not
+	// completed before, and unregister it if it fails to be pushed.
+	if !t.cfg.UseQueueMessageID {
+		t.registerRequest(txReq.MsgID, txReq, callbacks)
+		if _, err := t.requests.Push(txReq); err != nil {
+			t.unregisterRequest(txReq.MsgID)
+			return "", err
+		}
+		return txReq.MsgID, nil
+	}
+
+	// The queue message ID is only known once pushed, so the request is registered right after,
+	// before the main loop may process it.
+	t.pushMu.RLock()
+	defer t.pushMu.RUnlock()
 	queueID, err := t.requests.Push(txReq)
 	if err != nil {
 		return "", err
 	}
-	if t.cfg.UseQueueMessageID {
-		msgID = queueID
-	}
+	t.registerRequest(queueID, txReq, callbacks)
+	return queueID, nil
+}
 
+// registerRequest persists the given new tx request as queued and registers its callbacks.
+func (t *TxrV2) registerRequest(msgID string, txReq *types.Request, callbacks []Callback) {
+	t.storeMu.Lock()
+	t.recordRequest(context.Background(), msgID, txReq, store.StatusQueued)
+	t.storeMu.Unlock()
+
+	t.callbacksMu.Lock()
+	t.addCallbacks(msgID, callbacks)
+	t.callbacksMu.Unlock()
 	t.markState(types.StateQueued, msgID)
-	return msgID, nil
+}
+
+// unregisterRequest rolls back the registration of the tx request that failed to be queued.
+func (t *TxrV2) unregisterRequest(msgID string) {
+	if t.store != nil && msgID != "" {
+		t.storeMu.Lock()
+		if err := t.store.Delete(context.Background(), msgID); err != nil {
+			t.logger.Error("failed to delete persisted tx request", "msg", msgID, "err", err)
+		}
+		t.storeMu.Unlock()
+	}
+
+	t.callbacksMu.Lock()
+	delete(t.callbacks, msgID)
+	t.callbacksMu.Unlock()
+	t.removeStateTracking(msgID)
 }
 
 // ForceTxRequest immediately (whenever the sender is free from any previous sends) builds and
@@ -166,19 +314,26 @@ func (t *TxrV2) ForceTxRequest(
 		return "", err
 	}
 
+	t.storeMu.Lock()
+	t.recordRequest(ctx, txReq.MsgID, txReq, store.StatusBuilding)
+	t.storeMu.Unlock()
+
+	s := t.signers.pick(txReq.StickyKey())
 	if async {
 		go t.fire(
-			ctx,
+			ctx, s,
 			&tracker.Response{
 				MsgIDs: []string{txReq.MsgID}, InitialTimes: []time.Time{txReq.Time()},
+				MaxGasFeeCap: txReq.MaxGasFeeCap,
 			},
 			true, txReq.CallMsg,
 		)
 	} else {
 		t.fire(
-			ctx,
+			ctx, s,
 			&tracker.Response{
 				MsgIDs: []string{txReq.MsgID}, InitialTimes: []time.Time{txReq.Time()},
+				MaxGasFeeCap: txReq.MaxGasFeeCap,
 			},
 			true, txReq.CallMsg,
 		)
@@ -195,6 +350,17 @@ func (t *TxrV2) GetPreconfirmedState(msgID string) types.PreconfirmedState {
 	return t.preconfirmedStates[msgID]
 }
 
+// GetStatus returns the status of the given message ID along with its full history, including the
+// hashes of all the txs it was sent with and the receipt once it was mined or reverted. The record
+// is kept for the configured retention after the request is done. Returns nil if the message ID
+// is unknown.
+func (t *TxrV2) GetStatus(msgID string) (*store.Record, error) {
+	if t.store == nil {
+		return nil, errors.New("transactor is not set up")
+	}
+	return t.store.Get(context.Background(), msgID)
+}
+
 // markState marks the given preconfirmed state for the given message IDs.
 func (t *TxrV2) markState(state types.PreconfirmedState, msgIDs ...string) {
 	t.preconfirmedMu.Lock()
@@ -216,20 +382,50 @@ func (t *TxrV2) removeStateTracking(msgIDs ...string) {
 	}
 }
 
-// resendStaleTxns resends all the stale (pending) transactions in the mempool with bumped gas.
+// resendStaleTxns resends all the stale (pending) transactions of each signer in the mempool with
+// bumped gas, along with the message IDs of the persisted requests they contain, by signer and
+// nonce. Each tx is re-signed at its nonce, so that it replaces the stale one. The txs whose
+// bumped gas would exceed their max gas fee cap are tracked as is instead, while the txs that
+// fail to be rebuilt otherwise are left in the mempool.
 // NOTE: blocks until resending all the pending txs either error and/or are sent to the chain.
-func (t *TxrV2) resendStaleTxns(ctx context.Context, chain eth.Client) error {
-	txPoolContent, err := chain.TxPoolContentFrom(ctx, t.signerAddr)
-	if err != nil {
-		t.logger.Error("failed to get tx pool content from", "err", err)
-		return err
-	}
+func (t *TxrV2) resendStaleTxns(
+	ctx context.Context, chain eth.Client, msgIDs map[common.Address]map[uint64][]string,
+) error {
+	for _, s := range t.signers.signers {
+		txPoolContent, err := chain.TxPoolContentFrom(ctx, s.addr)
+		if err != nil {
+			t.logger.Error("failed to get tx pool content from", "signer", s.addr, "err", err)
+			return err
+		}
 
-	if pendingTxs := txPoolContent["pending"]; len(pendingTxs) > 0 {
-		t.logger.Info("🔄 resending stale (pending in txpool) txs", "count", len(pendingTxs))
-		for _, tx := range pendingTxs {
-			resp := &tracker.Response{Transaction: sender.BumpGas(tx)}
-			t.fire(ctx, resp, true, types.CallMsgFromTx(resp.Transaction))
+		if pendingTxs := txPoolContent["pending"]; len(pendingTxs) > 0 {
+			t.logger.Info(
+				"🔄 resending stale (pending in txpool) txs", "signer", s.addr, "count",
+				len(pendingTxs),
+			)
+			for _, tx := range pendingTxs {
+				resp := &tracker.Response{MsgIDs: msgIDs[s.addr][tx.Nonce()]}
+				resp.MaxGasFeeCap = t.maxGasFeeCapOf(ctx, resp.MsgIDs)
+				resp.Transaction, err = s.factory.RebuildCappedTransactionFromRequest(
+					ctx, resp.MaxGasFeeCap, types.CallMsgFromTx(sender.BumpGas(tx)), tx.Nonce(),
+				)
+				switch {
+				case errors.Is(err, factory.ErrMaxGasFeeCapExceeded):
+					t.logger.Warn(
+						"⛽ bumped gas fees of stale tx exceed the max, tracking it as is",
+						"signer", s.addr, "hash", tx.Hash(), "err", err,
+					)
+					resp.Signer, resp.Transaction = s.addr, tx
+					t.track(ctx, s, resp)
+				case err != nil:
+					t.logger.Error(
+						"failed to rebuild stale tx", "signer", s.addr, "hash", tx.Hash(),
+						"err", err,
+					)
+				default:
+					t.fire(ctx, s, resp, false)
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,98 @@
+package transactor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner signs txs with a local key.
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &testSigner{key: key}
+}
+
+func (s *testSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *testSigner) SignerFunc(_ context.Context, chainID *big.Int) (bind.SignerFn, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(s.key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return opts.Signer, nil
+}
+
+// newTestChain returns a mock chain whose mempool is empty.
+func newTestChain() *mocks.Client {
+	chain := new(mocks.Client)
+	chain.On("ChainID", mock.Anything).Return(big.NewInt(1), nil).Maybe()
+	chain.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(0), nil).Maybe()
+	chain.On("TxPoolContentFrom", mock.Anything, mock.Anything).Return(
+		map[string]map[uint64]*coretypes.Transaction{}, nil,
+	).Maybe()
+	return chain
+}
+
+// newTestContext returns an sdk context of the given chain.
+func newTestContext(chain *mocks.Client) *sdk.Context {
+	return sdk.NewContext(
+		context.Background(), chain, log.NewLogger(io.Discard, "test"), nil, nil,
+	)
+}
+
+func TestTransactorSetupTeardown(t *testing.T) {
+	txr, err := NewTransactor(Config{
+		EmptyQueueDelay: time.Millisecond, PendingNonceInterval: time.Second,
+	}, newTestSigner(t), nil)
+	require.NoError(t, err)
+	sCtx := newTestContext(newTestChain())
+
+	require.NoError(t, txr.Setup(sCtx))
+	require.NoError(t, txr.Teardown(sCtx))
+
+	// A batch dispatched while torn down is not fired until the next setup, which fires it with its
+	// own context.
+	fired := make(chan context.Context, 1)
+	txr.signers.signers[0].dispatch(
+		context.Background(), func(ctx context.Context) { fired <- ctx },
+	)
+	select {
+	case <-fired:
+		t.Fatal("batch fired while torn down")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	require.NoError(t, txr.Setup(sCtx))
+	var ctx context.Context
+	select {
+	case ctx = <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("batch not fired after setting up again")
+	}
+	require.NoError(t, ctx.Err())
+
+	// Tearing down stops all the loops of the setup, cancelling its context.
+	require.NoError(t, txr.Teardown(sCtx))
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.NoError(t, txr.Teardown(sCtx), "tearing down again is a no-op")
+}
@@ -22,6 +22,15 @@ type Request struct {
 	// MsgID is the (optional) user-provided string id for this tx request.
 	MsgID string
 
+	// Key is the (optional) sticky key for this tx request. If the transactor's signers are
+	// selected by sticky key, the requests with the same key are always sent from the same signer,
+	// in order. Defaults to the MsgID.
+	Key string
+
+	// MaxGasFeeCap is the (optional) max gas fee cap that this tx request may be sent with. If
+	// batched, the lowest max of the batch applies to the batched tx.
+	MaxGasFeeCap *big.Int
+
 	// initialTime is the time at which this tx was initially requested; filled in automatically.
 	initialTime time.Time
 }
@@ -49,6 +58,12 @@ func NewRequest(
 	}
 }
 
+// RestoreRequest returns the tx request with the given call msg and ID that was initially
+// requested at the given time, e.g. to requeue a request that was persisted before a restart.
+func RestoreRequest(msg *ethereum.CallMsg, msgID string, initialTime time.Time) *Request {
+	return &Request{CallMsg: msg, MsgID: msgID, initialTime: initialTime}
+}
+
 // Validate ensures that the initialTime is set on the tx request.
 func (r *Request) Validate() error {
 	if r.initialTime.Equal(time.Time{}) || (r.initialTime == time.Time{}) {
@@ -63,6 +78,15 @@ func (r *Request) Time() time.Time {
 	return r.initialTime
 }
 
+// StickyKey returns the key used to select the signer of this tx request, if selected by sticky
+// key.
+func (r *Request) StickyKey() string {
+	if r.Key != "" {
+		return r.Key
+	}
+	return r.MsgID
+}
+
 // String() implements fmt.Stringer.
 func (r *Request) String() string {
 	return r.MsgID
@@ -102,6 +126,17 @@ func (rs Requests) MsgIDs() []string {
 	return ids
 }
 
+// MaxGasFeeCap returns the lowest max gas fee cap of the requests, or nil if none has one.
+func (rs Requests) MaxGasFeeCap() *big.Int {
+	var maxGasFeeCap *big.Int
+	for _, r := range rs {
+		if r.MaxGasFeeCap != nil && (maxGasFeeCap == nil || r.MaxGasFeeCap.Cmp(maxGasFeeCap) < 0) {
+			maxGasFeeCap = r.MaxGasFeeCap
+		}
+	}
+	return maxGasFeeCap
+}
+
 func (rs Requests) Times() []time.Time {
 	times := make([]time.Time, len(rs))
 	for i, r := range rs {
@@ -16,3 +16,9 @@ func CallMsgFromTx(tx *coretypes.Transaction) *ethereum.CallMsg {
 		Data:      tx.Data(),
 	}
 }
+
+// CallResult is the result of a single call of a transaction, which may be batched.
+type CallResult struct {
+	Success    bool
+	ReturnData []byte
+}
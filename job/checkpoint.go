@@ -0,0 +1,84 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// checkpointPrefix prefixes the keys of the checkpoints in the DB.
+const checkpointPrefix = "checkpoint/"
+
+// Checkpoint is the progress of a job through its inputs, e.g. the position of the last log it
+// processed, or a cursor of its own.
+type Checkpoint struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	LogIndex    uint   `json:"logIndex"`
+	Cursor      string `json:"cursor,omitempty"`
+}
+
+// Before returns whether the given block number and log index come before the checkpoint's, i.e.
+// they were already processed.
+func (cp *Checkpoint) Before(blockNumber uint64, logIndex uint) bool {
+	return blockNumber < cp.BlockNumber ||
+		(blockNumber == cp.BlockNumber && logIndex <= cp.LogIndex)
+}
+
+// Checkpointed represents a job whose progress is checkpointed in the app's DB, so that it resumes
+// from its checkpoint after a restart instead of from now. The checkpoint of an input is saved
+// once it and all inputs received before it are executed successfully. Once an input fails, the
+// checkpoint is held before it until a restart, so that it is executed again. Jobs with a producer
+// of their own (e.g. polling jobs) may checkpoint a cursor of their own, which is saved once the
+// execution of the input it is returned for succeeds.
+type Checkpointed interface {
+	Basic
+	// CheckpointOf returns the checkpoint that is reached once the given input is executed, if
+	// the input has one.
+	CheckpointOf(input any) (Checkpoint, bool)
+	// RestoreCheckpoint is called before the job is set up with its last saved checkpoint, or nil
+	// if none was saved yet.
+	RestoreCheckpoint(ctx context.Context, cp *Checkpoint) error
+}
+
+// CheckpointStore saves the checkpoints of jobs in a key-value store.
+type CheckpointStore struct {
+	db ethdb.KeyValueStore
+}
+
+// NewCheckpointStore creates a new checkpoint store on top of the given DB.
+func NewCheckpointStore(db ethdb.KeyValueStore) *CheckpointStore {
+	return &CheckpointStore{db: db}
+}
+
+// Load returns the checkpoint of the job with the given key, or nil if none was saved.
+func (cs *CheckpointStore) Load(jobKey string) (*Checkpoint, error) {
+	key := []byte(checkpointPrefix + jobKey)
+	if has, err := cs.db.Has(key); err != nil || !has {
+		return nil, err
+	}
+
+	bz, err := cs.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	cp := new(Checkpoint)
+	if err = json.Unmarshal(bz, cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Save saves the checkpoint of the job with the given key.
+func (cs *CheckpointStore) Save(jobKey string, cp Checkpoint) error {
+	bz, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return cs.db.Put([]byte(checkpointPrefix+jobKey), bz)
+}
+
+// Delete deletes the checkpoint of the job with the given key, e.g. to start over from now.
+func (cs *CheckpointStore) Delete(jobKey string) error {
+	return cs.db.Delete([]byte(checkpointPrefix + jobKey))
+}
@@ -0,0 +1,34 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStore(t *testing.T) {
+	store := job.NewCheckpointStore(memorydb.New())
+
+	// No checkpoint is saved yet.
+	cp, err := store.Load("indexer")
+	require.NoError(t, err)
+	require.Nil(t, cp)
+
+	require.NoError(t, store.Save("indexer", job.Checkpoint{BlockNumber: 100, LogIndex: 3}))
+	cp, err = store.Load("indexer")
+	require.NoError(t, err)
+	require.Equal(t, &job.Checkpoint{BlockNumber: 100, LogIndex: 3}, cp)
+
+	// Logs at or before the checkpoint were already processed.
+	require.True(t, cp.Before(99, 10))
+	require.True(t, cp.Before(100, 3))
+	require.False(t, cp.Before(100, 4))
+	require.False(t, cp.Before(101, 0))
+
+	require.NoError(t, store.Delete("indexer"))
+	cp, err = store.Load("indexer")
+	require.NoError(t, err)
+	require.Nil(t, cp)
+}
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrDependencyCycle is returned when the dependencies of jobs form a cycle.
+var ErrDependencyCycle = errors.New("dependency cycle between jobs")
+
+// HasDependencies represents a job that depends on other jobs, given by their registry keys, e.g.
+// a liquidator job that depends on the transactor. A job is only set up and run once all of its
+// dependencies are, and is torn down before them.
+type HasDependencies interface {
+	Basic
+	Dependencies() []string
+}
+
+// SortByDependencies sorts the given jobs in topological order, such that every job comes after
+// all of its dependencies, as well as after the jobs it routes its results to in the given
+// pipelines. Jobs are otherwise kept in the given order. It returns an error if a job depends on,
+// or routes to, a job that is not given, or if the dependencies and routes form a cycle.
+func SortByDependencies(jobs []Basic, pipelines ...*Pipeline) ([]Basic, error) {
+	indices := make(map[string]int, len(jobs))
+	for i, j := range jobs {
+		indices[j.RegistryKey()] = i
+	}
+
+	// Count the dependencies of every job that are yet to be sorted, and keep track of the
+	// dependents of every job.
+	var (
+		pending    = make([]int, len(jobs))
+		dependents = make([][]int, len(jobs))
+	)
+	for i, j := range jobs {
+		var (
+			deps = Dependencies(j)
+			seen = make(map[string]struct{})
+		)
+		for _, p := range pipelines {
+			deps = append(deps, p.Downstream(j.RegistryKey())...)
+		}
+		for _, dep := range deps {
+			if _, ok := seen[dep]; ok {
+				continue
+			}
+			seen[dep] = struct{}{}
+
+			depIndex, ok := indices[dep]
+			if !ok {
+				return nil, fmt.Errorf(
+					"job %s depends on, or routes to, unknown job %s", j.RegistryKey(), dep,
+				)
+			}
+			pending[i]++
+			dependents[depIndex] = append(dependents[depIndex], i)
+		}
+	}
+
+	// Repeatedly take the first job (in the given order) whose dependencies are all sorted.
+	var (
+		sorted = make([]Basic, 0, len(jobs))
+		done   = make([]bool, len(jobs))
+	)
+	for len(sorted) < len(jobs) {
+		next := -1
+		for i := range jobs {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf(
+				"%w, among jobs: %s", ErrDependencyCycle, strings.Join(unsortedKeys(jobs, done), ", "),
+			)
+		}
+
+		done[next] = true
+		sorted = append(sorted, jobs[next])
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+		}
+	}
+	return sorted, nil
+}
+
+// Dependencies returns the registry keys of the jobs that the given job depends on, if any.
+func Dependencies(j Basic) []string {
+	if dj, ok := j.(HasDependencies); ok {
+		return slices.Clone(dj.Dependencies())
+	}
+	return nil
+}
+
+// unsortedKeys returns the registry keys of the jobs that are not done, i.e. the jobs that are in
+// or depend on a cycle.
+func unsortedKeys(jobs []Basic, done []bool) []string {
+	var keys []string
+	for i, j := range jobs {
+		if !done[i] {
+			keys = append(keys, j.RegistryKey())
+		}
+	}
+	return keys
+}
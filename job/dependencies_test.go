@@ -0,0 +1,84 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/stretchr/testify/require"
+)
+
+// depJob is a job that depends on the jobs with the given keys.
+type depJob struct {
+	key  string
+	deps []string
+}
+
+func (j *depJob) RegistryKey() string { return j.key }
+
+func (j *depJob) Execute(context.Context, any) (any, error) { return nil, nil }
+
+func (j *depJob) Dependencies() []string { return j.deps }
+
+func keys(jobs []job.Basic) []string {
+	keys := make([]string, len(jobs))
+	for i, j := range jobs {
+		keys[i] = j.RegistryKey()
+	}
+	return keys
+}
+
+func TestSortByDependencies(t *testing.T) {
+	sorted, err := job.SortByDependencies([]job.Basic{
+		&depJob{key: "liquidator", deps: []string{"transactor", "oracle"}},
+		&depJob{key: "oracle"},
+		&depJob{key: "transactor", deps: []string{"oracle"}},
+		&depJob{key: "indexer"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"oracle", "transactor", "liquidator", "indexer"}, keys(sorted))
+}
+
+func TestSortByDependenciesUnknown(t *testing.T) {
+	_, err := job.SortByDependencies([]job.Basic{
+		&depJob{key: "liquidator", deps: []string{"transactor"}},
+	})
+	require.ErrorContains(t, err, "unknown job transactor")
+}
+
+func TestSortByDependenciesCycle(t *testing.T) {
+	_, err := job.SortByDependencies([]job.Basic{
+		&depJob{key: "a", deps: []string{"c"}},
+		&depJob{key: "b", deps: []string{"a"}},
+		&depJob{key: "c", deps: []string{"b"}},
+		&depJob{key: "d"},
+	})
+	require.ErrorIs(t, err, job.ErrDependencyCycle)
+	require.ErrorContains(t, err, "a, b, c")
+}
+
+func TestSortByDependenciesPipeline(t *testing.T) {
+	pipeline := job.NewPipeline().
+		Chain("events", "decode", "db-writer").
+		FanOut("decode", "alerts")
+
+	// Downstream jobs come before the jobs that route their results to them.
+	sorted, err := job.SortByDependencies([]job.Basic{
+		&depJob{key: "events"},
+		&depJob{key: "decode"},
+		&depJob{key: "db-writer"},
+		&depJob{key: "alerts"},
+	}, pipeline)
+	require.NoError(t, err)
+	require.Equal(t, []string{"db-writer", "alerts", "decode", "events"}, keys(sorted))
+
+	// Routes must not form a cycle.
+	pipeline.Chain("db-writer", "events")
+	_, err = job.SortByDependencies([]job.Basic{
+		&depJob{key: "events"},
+		&depJob{key: "decode"},
+		&depJob{key: "db-writer"},
+		&depJob{key: "alerts"},
+	}, pipeline)
+	require.ErrorIs(t, err, job.ErrDependencyCycle)
+}
@@ -0,0 +1,109 @@
+package job
+
+import (
+	"context"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+)
+
+// Forwarder is embedded by the wrappers of basic jobs, e.g. subscriptions of a basic job to
+// ethereum events, to forward the optional methods of the wrapped job, so that its setup,
+// teardown, dependencies, policies and hooks are respected. Wrappers may still override any of
+// them.
+//
+// A wrapper therefore implements every optional interface, whether the wrapped job does or not.
+// The methods that the wrapped job does not implement return their zero value (e.g. a nil policy,
+// no dependencies or a no-op hook), which the job manager treats as not implemented.
+//
+// NOTE: the wrappers in x/jobs implement HasTeardownContext, i.e. `Teardown(context.Context)`,
+// instead of HasTeardown since they embed a Forwarder. Callers of their `Teardown()` must pass a
+// context instead, e.g. with the teardown's deadline; the wrapped job may implement either.
+type Forwarder struct {
+	Basic
+}
+
+// Forward returns a forwarder of the optional methods of the given job.
+func Forward(b Basic) Forwarder {
+	return Forwarder{Basic: b}
+}
+
+// Setup implements HasSetup.
+func (f Forwarder) Setup(ctx context.Context) error {
+	if setupJob, ok := f.Basic.(HasSetup); ok {
+		return setupJob.Setup(ctx)
+	}
+	return nil
+}
+
+// Teardown implements HasTeardownContext, forwarding to either teardown function.
+func (f Forwarder) Teardown(ctx context.Context) error {
+	switch teardownJob := f.Basic.(type) {
+	case HasTeardownContext:
+		return teardownJob.Teardown(ctx)
+	case HasTeardown:
+		return teardownJob.Teardown()
+	default:
+		return nil
+	}
+}
+
+// Optional implements Optional.
+func (f Forwarder) Optional() bool {
+	if optionalJob, ok := f.Basic.(Optional); ok {
+		return optionalJob.Optional()
+	}
+	return false
+}
+
+// Singleton implements Singleton.
+func (f Forwarder) Singleton() bool {
+	if singletonJob, ok := f.Basic.(Singleton); ok {
+		return singletonJob.Singleton()
+	}
+	return false
+}
+
+// Dependencies implements HasDependencies.
+func (f Forwarder) Dependencies() []string {
+	if depJob, ok := f.Basic.(HasDependencies); ok {
+		return depJob.Dependencies()
+	}
+	return nil
+}
+
+// ExecutionPolicy implements HasExecutionPolicy.
+func (f Forwarder) ExecutionPolicy() *jobtypes.ExecutionPolicy {
+	if policyJob, ok := f.Basic.(HasExecutionPolicy); ok {
+		return policyJob.ExecutionPolicy()
+	}
+	return nil
+}
+
+// OnResult implements HasOnResult.
+func (f Forwarder) OnResult(ctx context.Context, args any, res any) {
+	if hookJob, ok := f.Basic.(HasOnResult); ok {
+		hookJob.OnResult(ctx, args, res)
+	}
+}
+
+// OnError implements HasOnError.
+func (f Forwarder) OnError(ctx context.Context, args any, err error) {
+	if hookJob, ok := f.Basic.(HasOnError); ok {
+		hookJob.OnError(ctx, args, err)
+	}
+}
+
+// SubscriptionPolicy implements HasSubscriptionPolicy.
+func (f Forwarder) SubscriptionPolicy() *jobtypes.SubscriptionPolicy {
+	if policyJob, ok := f.Basic.(HasSubscriptionPolicy); ok {
+		return policyJob.SubscriptionPolicy()
+	}
+	return nil
+}
+
+// OnGiveUp implements HasOnGiveUp.
+func (f Forwarder) OnGiveUp(ctx context.Context, err error) {
+	if hookJob, ok := f.Basic.(HasOnGiveUp); ok {
+		hookJob.OnGiveUp(ctx, err)
+	}
+}
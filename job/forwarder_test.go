@@ -0,0 +1,30 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/stretchr/testify/require"
+)
+
+// wrapperJob wraps a basic job, overriding its dependencies.
+type wrapperJob struct {
+	job.Forwarder
+}
+
+func (w *wrapperJob) Dependencies() []string { return []string{"override"} }
+
+func TestForwarder(t *testing.T) {
+	var wrapped job.Basic = &wrapperJob{job.Forward(&depJob{key: "inner", deps: []string{"a"}})}
+
+	// The wrapped job's methods are forwarded, unless overridden by the wrapper.
+	require.Equal(t, "inner", wrapped.RegistryKey())
+	require.Equal(t, []string{"override"}, job.Dependencies(wrapped))
+	require.Equal(t, []string{"a"}, job.Forward(&depJob{deps: []string{"a"}}).Dependencies())
+
+	// Optional methods that the wrapped job does not implement default to their zero value.
+	require.NoError(t, wrapped.(job.HasSetup).Setup(context.Background()))
+	require.False(t, wrapped.(job.Singleton).Singleton())
+	require.Nil(t, wrapped.(job.HasExecutionPolicy).ExecutionPolicy())
+}
@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 )
 
 // Basic represents a basic job. Borrowing the terminology from inheritance, we can
@@ -25,12 +27,69 @@ type HasTeardown interface {
 	Teardown() error
 }
 
+// HasTeardownContext represents a job that has a teardown function which takes a context. The
+// given context has a deadline, after which the teardown is given up on.
+type HasTeardownContext interface {
+	Basic
+	Teardown(context.Context) error
+}
+
+// Optional represents a job that is not required for the app to run. An optional job that fails
+// to be set up is skipped (with the error logged) instead of failing the app's start.
+type Optional interface {
+	Basic
+	Optional() bool
+}
+
+// Singleton represents a job that must only be run by one replica of the app at a time, e.g. one
+// that sends transactions. If leader election is enabled, a singleton job is only run while its
+// replica is the leader, and is set up again every time the replica is elected.
+type Singleton interface {
+	Basic
+	Singleton() bool
+}
+
 // HasProducer represents a struct that defines a producer.
 type HasProducer interface {
 	Basic
 	Producer(ctx context.Context, pool WorkerPool) error
 }
 
+// HasExecutionPolicy represents a job that defines how its payloads are executed, e.g. on a
+// dedicated executor pool, with a limit on the number of concurrent executions, or with a
+// timeout and retries.
+type HasExecutionPolicy interface {
+	Basic
+	ExecutionPolicy() *jobtypes.ExecutionPolicy
+}
+
+// HasOnResult represents a job that is notified of the result of every successful execution.
+type HasOnResult interface {
+	Basic
+	OnResult(ctx context.Context, args any, res any)
+}
+
+// HasOnError represents a job that is notified of every execution that fails, after all retries
+// of its execution policy are exhausted.
+type HasOnError interface {
+	Basic
+	OnError(ctx context.Context, args any, err error)
+}
+
+// HasSubscriptionPolicy represents a subscription job that defines how it is resubscribed, e.g.
+// how long its subscription can go without receiving a value and how many times it is retried.
+type HasSubscriptionPolicy interface {
+	Basic
+	SubscriptionPolicy() *jobtypes.SubscriptionPolicy
+}
+
+// HasOnGiveUp represents a subscription job that is notified when it exhausts the retries of its
+// subscription policy, with the last error, after which it is no longer run.
+type HasOnGiveUp interface {
+	Basic
+	OnGiveUp(ctx context.Context, err error)
+}
+
 // HasMetrics represents a struct that defines metrics for
 // its internal functions.
 type HasMetrics interface {
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"context"
+)
+
+// Filter decides whether a result of an upstream job is routed to a downstream job.
+type Filter func(ctx context.Context, res any) bool
+
+// Route routes the results of the job From into the job To as its input, for every result that
+// the filter (if any) accepts. If DropWhenFull is set, results are dropped while the executor of
+// the job To is full, instead of the job From waiting for it to have room.
+type Route struct {
+	From         string
+	To           string
+	Filter       Filter
+	DropWhenFull bool
+}
+
+// Pipeline chains the output of jobs into downstream jobs as their input, e.g. an event
+// subscription → decode → enrich → DB writer. Every successful, non-nil result of a job is
+// executed by each of its downstream jobs on their own executor, as if produced by them, and
+// results are dropped for downstream jobs that are not running.
+//
+// Routing a result waits for the downstream job's executor to have room, so a slow downstream job
+// applies backpressure to its upstream jobs. Since the upstream executions are the ones waiting,
+// a downstream job should have its own pool (see HasExecutionPolicy) rather than share its
+// executor with its upstream jobs, or its routes should drop results when full (DropWhenFull).
+//
+// Jobs are given by their registry keys. A basic job with no producer of its own can be a stage
+// that is only fed by its upstream jobs. Jobs are set up after, and torn down before, their
+// downstream jobs, so the routes of all pipelines must not form a cycle.
+type Pipeline struct {
+	routes []Route
+}
+
+// NewPipeline creates a new, empty pipeline.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Chain routes the results of every given job into the next one.
+func (p *Pipeline) Chain(jobKeys ...string) *Pipeline {
+	for i := 1; i < len(jobKeys); i++ {
+		p.routes = append(p.routes, Route{From: jobKeys[i-1], To: jobKeys[i]})
+	}
+	return p
+}
+
+// FanOut routes the results of the given job into each of the given downstream jobs.
+func (p *Pipeline) FanOut(from string, to ...string) *Pipeline {
+	for _, t := range to {
+		p.routes = append(p.routes, Route{From: from, To: t})
+	}
+	return p
+}
+
+// FanIn routes the results of each of the given upstream jobs into the given job.
+func (p *Pipeline) FanIn(to string, from ...string) *Pipeline {
+	for _, f := range from {
+		p.routes = append(p.routes, Route{From: f, To: to})
+	}
+	return p
+}
+
+// Filter routes the results of the job from into the job to, only for the results that the given
+// filter accepts.
+func (p *Pipeline) Filter(from, to string, filter Filter) *Pipeline {
+	p.routes = append(p.routes, Route{From: from, To: to, Filter: filter})
+	return p
+}
+
+// DropWhenFull makes the routes from the job from into the job to drop the results while the
+// executor of the job to is full, rather than wait for it to have room.
+func (p *Pipeline) DropWhenFull(from, to string) *Pipeline {
+	for i := range p.routes {
+		if p.routes[i].From == from && p.routes[i].To == to {
+			p.routes[i].DropWhenFull = true
+		}
+	}
+	return p
+}
+
+// Routes returns all routes of the pipeline.
+func (p *Pipeline) Routes() []Route {
+	return p.routes
+}
+
+// Downstream returns the keys of the jobs that the given job routes its results to.
+func (p *Pipeline) Downstream(jobKey string) []string {
+	var downstream []string
+	for _, r := range p.routes {
+		if r.From == jobKey {
+			downstream = append(downstream, r.To)
+		}
+	}
+	return downstream
+}
+
+// IsDownstream returns whether any job routes its results to the given job.
+func (p *Pipeline) IsDownstream(jobKey string) bool {
+	for _, r := range p.routes {
+		if r.To == jobKey {
+			return true
+		}
+	}
+	return false
+}
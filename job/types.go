@@ -2,12 +2,20 @@ package job
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/berachain/offchain-sdk/client/eth"
 	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/tools/cron"
+	sdk "github.com/berachain/offchain-sdk/types"
 
 	"github.com/ethereum/go-ethereum"
 	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 // WrapJob wraps a basic job into a job that can be submitted to the worker pool.
@@ -19,12 +27,14 @@ func WrapJob(j Basic) HasProducer {
 		wrappedJob = WrapConditional(condJob)
 	} else if pollJob, ok := j.(Polling); ok { //nolint:govet // can't avoid.
 		wrappedJob = WrapPolling(pollJob)
+	} else if schedJob, ok := j.(Scheduled); ok { //nolint:govet // can't avoid.
+		wrappedJob = WrapScheduled(schedJob)
 	}
 	return wrappedJob
 }
 
-// After Basic jobs as explained in `job.go` the SDK currently
-// supports two other types of jobs, polling jobs and conditional jobs.
+// After Basic jobs as explained in `job.go` the SDK currently supports four other types of
+// jobs, polling jobs, conditional jobs, scheduled jobs and block interval jobs.
 
 // ============================================
 // Polling Jobs
@@ -41,7 +51,7 @@ type Polling interface {
 // polling jobs are simply conditional jobs where `Condition()` always returns true.
 // Cute little double wrap that allows us to re-use the producer from `conditional`.
 func WrapPolling(c Polling) HasProducer {
-	return &conditional{&polling{c}}
+	return &conditional{Conditional: &polling{c}, job: c}
 }
 
 // Remember, polling is just a conditional job where the condition is always true.
@@ -69,12 +79,16 @@ type Conditional interface {
 
 // Wrap Conditional, wraps a conditional job to conform to the producer interface.
 func WrapConditional(c Conditional) HasProducer {
-	return &conditional{c}
+	return &conditional{Conditional: c, job: c}
 }
 
 // conditional is a wrapper for a conditional job.
 type conditional struct {
 	Conditional
+
+	// job is the original job that was wrapped, which is executed in the payloads so that its
+	// execution policy and hooks are respected.
+	job Basic
 }
 
 // ConditionalProducer produces a job when the condition is met.
@@ -87,7 +101,7 @@ func (cj *conditional) Producer(ctx context.Context, pool WorkerPool) error {
 		default:
 			// Check if the condition is true.
 			if cj.Condition(ctx) {
-				pool.SubmitAndWait(jobtypes.NewPayload(ctx, cj, nil).Execute)
+				pool.SubmitAndWait(jobtypes.NewPayload(ctx, cj.job, nil).Execute)
 			}
 		}
 
@@ -99,6 +113,203 @@ func (cj *conditional) Producer(ctx context.Context, pool WorkerPool) error {
 	}
 }
 
+// ============================================
+// Scheduled Jobs
+// ============================================
+
+// MissedRunPolicy defines what a scheduled job does with runs that were missed, i.e. runs whose
+// scheduled time passed while the previous run was still executing.
+type MissedRunPolicy uint8
+
+const (
+	// MissedRunsSkip skips all missed runs and waits for the next scheduled time.
+	MissedRunsSkip MissedRunPolicy = iota
+	// MissedRunsCatchUp executes every missed run, one after the other, as soon as possible.
+	MissedRunsCatchUp
+)
+
+// Schedule defines when a scheduled job runs.
+type Schedule struct {
+	// Cron is the cron expression of the schedule, either in the standard 5 field format or with
+	// a leading seconds field, e.g. "0 */5 * * * *" runs every 5 minutes.
+	Cron string
+	// Location is the timezone that the cron expression is evaluated in. Defaults to UTC.
+	Location *time.Location
+	// MissedRuns is the policy for runs missed while the job was still executing.
+	MissedRuns MissedRunPolicy
+	// Jitter, if non-zero, delays every run by a random duration in [0, Jitter), e.g. to avoid
+	// many replicas hitting the same RPC at the same time.
+	Jitter time.Duration
+}
+
+// Scheduled represents a scheduled job. Scheduled jobs are run at the times matching a cron
+// expression. The scheduled time of the run (as a time.Time) is passed as the job's input.
+type Scheduled interface {
+	Basic
+	Schedule(ctx context.Context) Schedule
+}
+
+// WrapScheduled wraps a scheduled job to conform to the producer interface.
+func WrapScheduled(s Scheduled) HasProducer {
+	return &scheduled{s}
+}
+
+// scheduled is a wrapper for a scheduled job.
+type scheduled struct {
+	Scheduled
+}
+
+// Producer produces a job at every scheduled time.
+func (sj *scheduled) Producer(ctx context.Context, pool WorkerPool) error {
+	sched := sj.Schedule(ctx)
+	cronSched, err := cron.Parse(sched.Cron)
+	if err != nil {
+		return err
+	}
+	loc := sched.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	next := cronSched.Next(time.Now().In(loc))
+	for !next.IsZero() {
+		select {
+		// If the context is cancelled, return.
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(next) + randomJitter(sched.Jitter)):
+			pool.SubmitAndWait(jobtypes.NewPayload(ctx, sj.Scheduled, next).Execute)
+		}
+
+		// Determine the next run. When catching up, missed runs are in the past and thus will
+		// be executed right away.
+		next = cronSched.Next(next)
+		if now := time.Now().In(loc); sched.MissedRuns == MissedRunsSkip && next.Before(now) {
+			next = cronSched.Next(now)
+		}
+	}
+	return fmt.Errorf("cron expression %q never matches", sched.Cron)
+}
+
+// randomJitter returns a random duration in [0, jitter).
+func randomJitter(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	random, _ := rand.Int(rand.Reader, big.NewInt(int64(jitter)))
+	if random == nil {
+		return 0
+	}
+	return time.Duration(random.Int64())
+}
+
+// ============================================
+// Block Interval Jobs
+// ============================================
+
+// BlockInterval represents a block interval job. Block interval jobs are run at every block
+// height that is a multiple of the given interval, e.g. an interval of 10 runs the job every 10
+// blocks. The header of the block (as a *coretypes.Header) is passed as the job's input.
+type BlockInterval interface {
+	Basic
+	BlockInterval(ctx context.Context) uint64
+}
+
+// BlockConditional represents a block interval job that is only run at the block heights for
+// which the condition holds, e.g. a block interval of 1 with a condition runs the job at every
+// block height matching the condition.
+type BlockConditional interface {
+	BlockInterval
+	BlockCondition(ctx context.Context, height uint64) bool
+}
+
+// WrapBlockInterval wraps a block interval job into a block header subscription that delivers
+// the header of every qualifying block height exactly once, even across resubscriptions. The
+// subscription is checkpointed, so that it resumes from the last processed height on restart.
+func WrapBlockInterval(b BlockInterval) BlockHeaderSub {
+	return &blockInterval{BlockInterval: b}
+}
+
+// blockInterval is a wrapper for a block interval job.
+type blockInterval struct {
+	BlockInterval
+
+	// cursor follows the headers from the last block height that was processed. It is kept across
+	// resubscriptions so that no height is skipped or delivered twice.
+	cursor eth.HeaderCursor
+}
+
+// Subscribe subscribes to new block headers, or polls for them if no websocket connection is
+// available, and delivers the headers of the qualifying block heights.
+func (bj *blockInterval) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan *coretypes.Header, error) {
+	chain := sdk.UnwrapContext(ctx).Chain()
+	heads, headSub, err := chain.SubscribeNewHead(ctx)
+	if errors.Is(err, eth.ErrClientNotFound) {
+		heads, headSub = eth.PollNewHeads(ctx, chain, eth.DefaultPollInterval)
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	sub, ch := bj.cursor.Follow(ctx, chain, heads, headSub, func(height uint64) bool {
+		return bj.qualifies(ctx, height)
+	})
+	return sub, ch, nil
+}
+
+// Unsubscribe is a no-op, the subscription returned by Subscribe is unsubscribed by the caller.
+func (bj *blockInterval) Unsubscribe(context.Context) {}
+
+// CheckpointOf implements Checkpointed, the checkpoint of a header is its height.
+func (bj *blockInterval) CheckpointOf(input any) (Checkpoint, bool) {
+	header, ok := input.(*coretypes.Header)
+	if !ok || header == nil {
+		return Checkpoint{}, false
+	}
+	return Checkpoint{BlockNumber: header.Number.Uint64()}, true
+}
+
+// RestoreCheckpoint implements Checkpointed, resuming from the checkpoint's height.
+func (bj *blockInterval) RestoreCheckpoint(_ context.Context, cp *Checkpoint) error {
+	if cp != nil {
+		bj.cursor.Restore(cp.BlockNumber)
+	}
+	return nil
+}
+
+// qualifies returns whether the job should run at the given block height.
+func (bj *blockInterval) qualifies(ctx context.Context, height uint64) bool {
+	if interval := bj.BlockInterval.BlockInterval(ctx); interval > 1 && height%interval != 0 {
+		return false
+	}
+	if condJob, ok := bj.BlockInterval.(BlockConditional); ok {
+		return condJob.BlockCondition(ctx, height)
+	}
+	return true
+}
+
+// ============================================
+// Subscription Jobs
+// ============================================
+
+// ErrSubscriptionClosed is returned by a subscription whose source closed its channel, upon which
+// the job is resubscribed.
+var ErrSubscriptionClosed = errors.New("subscription channel closed")
+
+// Subscription represents a job that subscribes to a source of values of type T, e.g. an ethereum
+// subscription, a message queue or a webhook. Every value received is passed as the job's input.
+// The returned ethereum.Subscription reports the errors of the source, upon which the job is
+// resubscribed. It may be nil for sources that never fail.
+//
+// Subscriptions to values of any type other than `any` must be registered wrapped with
+// `WrapSubscription`.
+type Subscription[T any] interface {
+	Basic
+	Subscribe(ctx context.Context) (ethereum.Subscription, chan T, error)
+	Unsubscribe(ctx context.Context)
+}
+
 // Subscribable represents a subscribable job.
 type Subscribable interface {
 	Basic
@@ -107,14 +318,102 @@ type Subscribable interface {
 
 // EthSubscribable represents a subscription to an ethereum event.
 type EthSubscribable interface {
-	Basic
-	Subscribe(ctx context.Context) (ethereum.Subscription, chan coretypes.Log, error)
-	Unsubscribe(ctx context.Context)
+	Subscription[coretypes.Log]
 }
 
 // BlockHeaderSub represents a block watcher job.
 type BlockHeaderSub interface {
-	Basic
-	Subscribe(ctx context.Context) (ethereum.Subscription, chan *coretypes.Header, error)
-	Unsubscribe(ctx context.Context)
+	Subscription[*coretypes.Header]
+}
+
+// PendingTxSub represents a subscription to pending transactions, i.e. ones in the mempool.
+type PendingTxSub interface {
+	Subscription[*coretypes.Transaction]
+}
+
+// WrapSubscribable wraps a subscribable job into a subscription job whose source never fails.
+func WrapSubscribable(s Subscribable) Subscription[any] {
+	return &subscribable{s}
+}
+
+// subscribable is a wrapper for a subscribable job.
+type subscribable struct {
+	Subscribable
+}
+
+// Subscribe returns the channel of the subscribable job, without a subscription.
+func (sj *subscribable) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan any, error) {
+	return nil, sj.Subscribable.Subscribe(ctx), nil
+}
+
+// Unsubscribe is a no-op, subscribable jobs cannot be unsubscribed.
+func (sj *subscribable) Unsubscribe(context.Context) {}
+
+// WrapSubscription wraps a subscription to values of any type into a subscription job that can be
+// registered, forwarding the optional methods of the wrapped job.
+func WrapSubscription[T any](s Subscription[T]) Subscription[any] {
+	return &subscription[T]{Subscription: s, Forwarder: Forward(s)}
+}
+
+// subscription is a wrapper for a subscription job to values of type T.
+type subscription[T any] struct {
+	Subscription[T]
+	Forwarder
+}
+
+// Subscribe subscribes the wrapped job and forwards every value received from it.
+func (sj *subscription[T]) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan any, error) {
+	innerSub, innerCh, err := sj.Subscription.Subscribe(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sources that never fail have no subscription, whose nil error channel never fires.
+	var innerErrs <-chan error
+	if innerSub != nil {
+		innerErrs = innerSub.Err()
+	}
+
+	ch := make(chan any)
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		if innerSub != nil {
+			defer innerSub.Unsubscribe()
+		}
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err = <-innerErrs:
+				return err
+			case val, ok := <-innerCh:
+				if !ok {
+					return ErrSubscriptionClosed
+				}
+				select {
+				case <-quit:
+					return nil
+				case ch <- val:
+				}
+			}
+		}
+	})
+	return sub, ch, nil
+}
+
+func (sj *subscription[T]) CheckpointOf(input any) (Checkpoint, bool) {
+	if checkpointedJob, ok := sj.Subscription.(Checkpointed); ok {
+		return checkpointedJob.CheckpointOf(input)
+	}
+	return Checkpoint{}, false
+}
+
+func (sj *subscription[T]) RestoreCheckpoint(ctx context.Context, cp *Checkpoint) error {
+	if checkpointedJob, ok := sj.Subscription.(Checkpointed); ok {
+		return checkpointedJob.RestoreCheckpoint(ctx, cp)
+	}
+	return nil
 }
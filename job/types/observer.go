@@ -0,0 +1,27 @@
+package types
+
+import "context"
+
+// Observer is notified of the executions of jobs, e.g. to keep track of their status.
+type Observer interface {
+	// ExecutionStarted is called before a job is executed.
+	ExecutionStarted(job string)
+	// ExecutionFinished is called once an execution of a job with the given input, including all
+	// its retries, is finished, with the final error if it failed.
+	ExecutionFinished(job string, input any, err error)
+}
+
+// observerKey is the context key of the observer.
+type observerKey struct{}
+
+// ContextWithObserver returns a copy of the context that carries the given observer, which is then
+// notified of every job executed with the context.
+func ContextWithObserver(ctx context.Context, obs Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, obs)
+}
+
+// observerFromContext returns the observer carried by the context, if any.
+func observerFromContext(ctx context.Context) Observer {
+	obs, _ := ctx.Value(observerKey{}).(Observer)
+	return obs
+}
@@ -2,12 +2,39 @@ package types
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
+	sdk "github.com/berachain/offchain-sdk/types"
 )
 
 type Executable interface {
 	Execute(context.Context, any) (any, error)
 }
 
+// The optional interfaces of a job that a payload respects on execution. These mirror
+// `job.HasExecutionPolicy`, `job.HasOnResult` and `job.HasOnError`.
+type (
+	keyed interface {
+		RegistryKey() string
+	}
+
+	policied interface {
+		ExecutionPolicy() *ExecutionPolicy
+	}
+
+	resultHook interface {
+		OnResult(ctx context.Context, args any, res any)
+	}
+
+	errorHook interface {
+		OnError(ctx context.Context, args any, err error)
+	}
+)
+
 // Payload encapsulates a job and its input into a neat package to
 // be executed by another thread.
 type Payload struct {
@@ -30,7 +57,143 @@ func NewPayload(ctx context.Context, job Executable, args any) *Payload {
 	}
 }
 
-// Execute executes the job and returns the result.
+// Execute executes the job, see Run. It is the task that is submitted to the worker pools.
 func (p Payload) Execute() {
-	_, _ = p.job.Execute(p.ctx, p.args)
+	_ = p.Run()
+}
+
+// Run executes the job according to its execution policy, if it has one, retrying failed
+// attempts. The final outcome is logged, recorded in metrics and passed on to the job's OnResult
+// or OnError hook, as well as to the context's observer (if any). A non-nil result is then routed
+// by the context's router (if any) into the downstream jobs. It returns the error of the final
+// attempt, if it failed.
+func (p Payload) Run() error {
+	var (
+		policy  = p.policy()
+		backoff = policy.retryBackoff()
+		start   = time.Now()
+		res     any
+		err     error
+	)
+
+	if obs := observerFromContext(p.ctx); obs != nil {
+		obs.ExecutionStarted(p.jobKey())
+		defer func() { obs.ExecutionFinished(p.jobKey(), p.args, err) }()
+	}
+
+retry:
+	for attempt := uint(0); ; attempt++ {
+		if res, err = p.executeOnce(policy); err == nil {
+			break
+		}
+
+		// Stop retrying if out of retries or the job is shutting down.
+		if attempt >= policy.MaxRetries || p.ctx.Err() != nil {
+			break
+		}
+
+		p.logger().Warn(
+			"job execution failed, retrying...",
+			"job", p.jobKey(), "attempt", attempt+1, "backoff", backoff, "err", err,
+		)
+		p.recordMetric("job.execution.retry")
+		select {
+		case <-p.ctx.Done():
+			// The job is shutting down during the backoff, so give up on the failed execution.
+			err = fmt.Errorf("%w: last error: %w", p.ctx.Err(), err)
+			break retry
+		case <-time.After(backoff):
+		}
+		backoff = policy.nextBackoff(backoff)
+	}
+
+	if metrics := p.metrics(); metrics != nil {
+		metrics.Time("job.execution.duration", time.Since(start), p.tags()...)
+	}
+
+	if err != nil {
+		p.logger().Error(
+			"job execution failed", "job", p.jobKey(), "duration", time.Since(start), "err", err,
+		)
+		if errors.Is(err, context.DeadlineExceeded) {
+			p.recordMetric("job.execution.timeout")
+		}
+		p.recordMetric("job.execution.error")
+		if hook, ok := p.job.(errorHook); ok {
+			hook.OnError(p.ctx, p.args, err)
+		}
+		return err
+	}
+
+	p.logger().Debug("job execution succeeded", "job", p.jobKey(), "duration", time.Since(start))
+	p.recordMetric("job.execution.success")
+	if hook, ok := p.job.(resultHook); ok {
+		hook.OnResult(p.ctx, p.args, res)
+	}
+	if router := routerFromContext(p.ctx); router != nil && res != nil {
+		router.RouteResult(p.ctx, p.jobKey(), res)
+	}
+	return nil
+}
+
+// executeOnce executes a single attempt of the job, bounded by the policy's timeout if set.
+func (p Payload) executeOnce(policy *ExecutionPolicy) (any, error) {
+	if policy.Timeout == 0 {
+		return p.job.Execute(p.ctx, p.args)
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, policy.Timeout)
+	defer cancel()
+
+	// Jobs expect to unwrap an sdk.Context, so keep the deadline context as one.
+	if sCtx, ok := p.ctx.(*sdk.Context); ok {
+		ctx = sCtx.WithContext(ctx)
+	}
+	return p.job.Execute(ctx, p.args)
+}
+
+// policy returns the job's execution policy, or an empty policy if it has none.
+func (p Payload) policy() *ExecutionPolicy {
+	if pj, ok := p.job.(policied); ok {
+		if policy := pj.ExecutionPolicy(); policy != nil {
+			return policy
+		}
+	}
+	return &ExecutionPolicy{}
+}
+
+// jobKey returns the registry key of the job, if it has one.
+func (p Payload) jobKey() string {
+	if kj, ok := p.job.(keyed); ok {
+		return kj.RegistryKey()
+	}
+	return "unknown"
+}
+
+// logger returns the logger of the sdk context, or a no-op logger otherwise.
+func (p Payload) logger() log.Logger {
+	if sCtx, ok := p.ctx.(*sdk.Context); ok && sCtx.Logger() != nil {
+		return sCtx.Logger()
+	}
+	return log.NewNopLogger()
+}
+
+// metrics returns the metrics of the sdk context, if available.
+func (p Payload) metrics() telemetry.Metrics {
+	if sCtx, ok := p.ctx.(*sdk.Context); ok {
+		return sCtx.Metrics()
+	}
+	return nil
+}
+
+// recordMetric increments the given counter metric for the job, if metrics are available.
+func (p Payload) recordMetric(name string) {
+	if metrics := p.metrics(); metrics != nil {
+		metrics.IncMonotonic(name, p.tags()...)
+	}
+}
+
+// tags returns the metric tags for the job.
+func (p Payload) tags() []string {
+	return telemetry.ParseLabelPairsToTags([]string{"job"}, []string{p.jobKey()})
 }
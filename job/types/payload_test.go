@@ -0,0 +1,145 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyJob fails the first `failures` executions and records its hook calls.
+type flakyJob struct {
+	policy   *jobtypes.ExecutionPolicy
+	failures int
+	delay    time.Duration
+
+	executions int
+	result     any
+	err        error
+}
+
+func (j *flakyJob) RegistryKey() string { return "flaky" }
+
+func (j *flakyJob) ExecutionPolicy() *jobtypes.ExecutionPolicy { return j.policy }
+
+func (j *flakyJob) Execute(ctx context.Context, _ any) (any, error) {
+	sdk.UnwrapContext(ctx) // jobs must still be able to unwrap the sdk context
+	j.executions++
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(j.delay):
+	}
+	if j.executions <= j.failures {
+		return nil, errors.New("flaky")
+	}
+	return j.executions, nil
+}
+
+func (j *flakyJob) OnResult(_ context.Context, _ any, res any) { j.result = res }
+
+func (j *flakyJob) OnError(_ context.Context, _ any, err error) { j.err = err }
+
+func newTestContext() *sdk.Context {
+	return sdk.NewContext(
+		context.Background(), nil, log.NewLogger(io.Discard, "test-runner"), nil, nil,
+	)
+}
+
+// TestPayloadRetries tests that failed executions are retried and the result is passed to the
+// OnResult hook.
+func TestPayloadRetries(t *testing.T) {
+	j := &flakyJob{
+		policy:   &jobtypes.ExecutionPolicy{MaxRetries: 2, RetryBackoff: time.Millisecond},
+		failures: 2,
+	}
+	jobtypes.NewPayload(newTestContext(), j, nil).Execute()
+
+	require.Equal(t, 3, j.executions)
+	require.Equal(t, 3, j.result)
+	require.NoError(t, j.err)
+}
+
+// TestPayloadRetriesExhausted tests that the OnError hook is called once all retries fail.
+func TestPayloadRetriesExhausted(t *testing.T) {
+	j := &flakyJob{
+		policy:   &jobtypes.ExecutionPolicy{MaxRetries: 1, RetryBackoff: time.Millisecond},
+		failures: 5,
+	}
+	jobtypes.NewPayload(newTestContext(), j, nil).Execute()
+
+	require.Equal(t, 2, j.executions)
+	require.Nil(t, j.result)
+	require.EqualError(t, j.err, "flaky")
+}
+
+// TestPayloadCancelledDuringBackoff tests that a job cancelled during its retry backoff is not
+// executed again, failing with the cancellation and the last error.
+func TestPayloadCancelledDuringBackoff(t *testing.T) {
+	j := &flakyJob{
+		policy:   &jobtypes.ExecutionPolicy{MaxRetries: 3, RetryBackoff: time.Minute},
+		failures: 5,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	sCtx := sdk.NewContext(ctx, nil, log.NewLogger(io.Discard, "test-runner"), nil, nil)
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := jobtypes.NewPayload(sCtx, j, nil).Run()
+
+	require.Equal(t, 1, j.executions)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorContains(t, err, "flaky")
+	require.Equal(t, err, j.err)
+}
+
+// TestPayloadDefaultRetryBackoff tests that retries wait the default backoff if the policy does
+// not set one.
+func TestPayloadDefaultRetryBackoff(t *testing.T) {
+	j := &flakyJob{policy: &jobtypes.ExecutionPolicy{MaxRetries: 1}, failures: 1}
+	start := time.Now()
+	jobtypes.NewPayload(newTestContext(), j, nil).Execute()
+
+	require.Equal(t, 2, j.executions)
+	require.GreaterOrEqual(t, time.Since(start), jobtypes.DefaultRetryBackoff)
+}
+
+// TestPayloadTimeout tests that every execution attempt is bounded by the policy's timeout.
+func TestPayloadTimeout(t *testing.T) {
+	j := &flakyJob{
+		policy: &jobtypes.ExecutionPolicy{Timeout: 10 * time.Millisecond},
+		delay:  time.Second,
+	}
+	jobtypes.NewPayload(newTestContext(), j, nil).Execute()
+
+	require.Equal(t, 1, j.executions)
+	require.ErrorIs(t, j.err, context.DeadlineExceeded)
+}
+
+// routerFunc routes the results of jobs with a function.
+type routerFunc func(ctx context.Context, job string, res any)
+
+func (f routerFunc) RouteResult(ctx context.Context, job string, res any) { f(ctx, job, res) }
+
+// TestPayloadRoutesResult tests that the results of successful executions are routed by the
+// context's router.
+func TestPayloadRoutesResult(t *testing.T) {
+	var routed []any
+	ctx := newTestContext()
+	ctx = ctx.WithContext(jobtypes.ContextWithRouter(ctx, routerFunc(
+		func(_ context.Context, job string, res any) {
+			require.Equal(t, "flaky", job)
+			routed = append(routed, res)
+		},
+	)))
+
+	// Only successful executions are routed.
+	j := &flakyJob{failures: 1}
+	jobtypes.NewPayload(ctx, j, nil).Execute()
+	jobtypes.NewPayload(ctx, j, nil).Execute()
+	require.Equal(t, []any{2}, routed)
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"time"
+
+	"github.com/berachain/offchain-sdk/worker"
+)
+
+// DefaultRetryBackoff is the wait before the first retry of a failed execution, if the execution
+// policy of the job does not set one.
+const DefaultRetryBackoff = time.Second
+
+// ExecutionPolicy defines how the payloads of a job are executed.
+type ExecutionPolicy struct {
+	// Pool, if set, gives the job its own dedicated executor pool, isolating its payloads from
+	// the payloads of all other jobs. Unset fields fall back to the shared executor pool's config.
+	Pool *worker.PoolConfig
+
+	// MaxConcurrency, if non-zero, caps the number of the job's payloads that can be in flight
+	// (queued or running) at once. Producers block on submitting until a slot frees up.
+	MaxConcurrency uint16
+
+	// Timeout, if non-zero, bounds every execution attempt of the job with a context deadline.
+	Timeout time.Duration
+
+	// MaxRetries is the number of times a failed execution is retried before giving up.
+	MaxRetries uint
+	// RetryBackoff is how long to wait before the first retry, DefaultRetryBackoff if unset. It
+	// is doubled after every retry, up to MaxRetryBackoff (if set).
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the wait between retries.
+	MaxRetryBackoff time.Duration
+}
+
+// retryBackoff returns the wait before the first retry.
+func (p *ExecutionPolicy) retryBackoff() time.Duration {
+	if p.RetryBackoff > 0 {
+		return p.RetryBackoff
+	}
+	return DefaultRetryBackoff
+}
+
+// nextBackoff returns the wait before the retry after the given one.
+func (p *ExecutionPolicy) nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if p.MaxRetryBackoff > 0 && backoff > p.MaxRetryBackoff {
+		backoff = p.MaxRetryBackoff
+	}
+	return backoff
+}
+
+// SubscriptionPolicy defines how a subscription job is resubscribed when its subscription fails
+// or goes stale. Unset fields fall back to the job manager's config.
+type SubscriptionPolicy struct {
+	// StaleTimeout is how long a subscription can go without receiving a value before it is
+	// considered stale and is resubscribed.
+	StaleTimeout time.Duration
+
+	// MaxRetries, if non-zero, is the number of consecutive resubscriptions (without receiving a
+	// value in between) after which the job gives up and is no longer run.
+	MaxRetries uint
+	// RetryBackoff is how long to wait before the first resubscription. It is multiplied by
+	// BackoffMultiplier after every retry, up to MaxRetryBackoff.
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the wait between resubscriptions.
+	MaxRetryBackoff time.Duration
+	// BackoffMultiplier is the factor the wait between resubscriptions grows by after every retry.
+	BackoffMultiplier uint
+}
+
+// WithDefaults returns a copy of the policy where every unset (zero-valued) field is filled in
+// from the given defaults.
+func (p SubscriptionPolicy) WithDefaults(defaults *SubscriptionPolicy) *SubscriptionPolicy {
+	if p.StaleTimeout == 0 {
+		p.StaleTimeout = defaults.StaleTimeout
+	}
+	if p.MaxRetries == 0 {
+		p.MaxRetries = defaults.MaxRetries
+	}
+	if p.RetryBackoff == 0 {
+		p.RetryBackoff = defaults.RetryBackoff
+	}
+	if p.MaxRetryBackoff == 0 {
+		p.MaxRetryBackoff = defaults.MaxRetryBackoff
+	}
+	if p.BackoffMultiplier == 0 {
+		p.BackoffMultiplier = defaults.BackoffMultiplier
+	}
+	return &p
+}
+
+// NextBackoff returns the wait before the resubscription after the given one.
+func (p *SubscriptionPolicy) NextBackoff(backoff time.Duration) time.Duration {
+	backoff *= time.Duration(p.BackoffMultiplier)
+	if p.MaxRetryBackoff > 0 && backoff > p.MaxRetryBackoff {
+		backoff = p.MaxRetryBackoff
+	}
+	return backoff
+}
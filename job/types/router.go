@@ -0,0 +1,25 @@
+package types
+
+import "context"
+
+// Router routes the results of jobs into downstream jobs, e.g. the stages of a pipeline.
+type Router interface {
+	// RouteResult is called with the result of every successful execution of a job, unless the
+	// result is nil.
+	RouteResult(ctx context.Context, job string, res any)
+}
+
+// routerKey is the context key of the router.
+type routerKey struct{}
+
+// ContextWithRouter returns a copy of the context that carries the given router, which is then
+// given the results of every job executed with the context.
+func ContextWithRouter(ctx context.Context, router Router) context.Context {
+	return context.WithValue(ctx, routerKey{}, router)
+}
+
+// routerFromContext returns the router carried by the context, if any.
+func routerFromContext(ctx context.Context) Router {
+	router, _ := ctx.Value(routerKey{}).(Router)
+	return router
+}
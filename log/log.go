@@ -104,3 +104,8 @@ func NewBlankLogger(dst io.Writer) Logger {
 	logger := log.NewLogger(dst, opts...)
 	return &loggerImpl{logger}
 }
+
+// NewNopLogger creates a new logger that discards all logs.
+func NewNopLogger() Logger {
+	return &loggerImpl{log.NewNopLogger()}
+}
@@ -7,8 +7,12 @@ type Config struct {
 
 // HTTP represents the http config object for the http server.
 type HTTP struct {
-	Host string // optional, empty corresponds to "0.0.0.0"
-	Port uint64
+	Host  string // optional, empty corresponds to "0.0.0.0"
+	Port  uint64
+	Admin bool // optional, serves the admin API of the jobs under "/admin/"
+	// AdminToken is the bearer token that requests to the admin API must carry, required if the
+	// admin API is served.
+	AdminToken string
 }
 
 // Enabled returns true if the http server is enabled (i.e. the Port is non-zero).
@@ -27,9 +27,11 @@ type Server struct {
 	cfg    *Config
 	logger log.Logger
 
-	mux    *http.ServeMux
-	srv    *http.Server
-	closer sync.Once
+	mux *http.ServeMux
+
+	mu      sync.Mutex
+	srv     *http.Server // set once started
+	stopped bool
 
 	middlewares []Middleware
 }
@@ -64,15 +66,23 @@ func (s *Server) applyMiddlewares() http.Handler {
 	return h
 }
 
-// Start starts the server. It is blocking so must run in a go-routine.
+// Start starts the server. It is blocking so must run in a go-routine. The server is not started
+// if it was already stopped.
 func (s *Server) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
 	s.srv = &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", s.cfg.HTTP.Host, s.cfg.HTTP.Port),
 		Handler:           s.applyMiddlewares(),
 		ReadHeaderTimeout: defaultReadHeaderTimeout,
 	}
+	srv := s.srv
+	s.mu.Unlock()
 
-	if err := s.srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 		s.logger.Error("HTTP server errored", "err", err)
 	} else {
 		s.logger.Info("HTTP server closed")
@@ -82,11 +92,19 @@ func (s *Server) Start(ctx context.Context) {
 	s.Stop()
 }
 
-// Stop stops the server.
+// Stop stops the server, if it was started. It may be called more than once.
 func (s *Server) Stop() {
-	s.closer.Do(func() {
-		if err := s.srv.Close(); err != nil {
-			s.logger.Error("HTTP server close error", "err", err)
-		}
-	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	if s.srv == nil {
+		return
+	}
+	if err := s.srv.Close(); err != nil {
+		s.logger.Error("HTTP server close error", "err", err)
+	}
 }
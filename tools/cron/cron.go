@@ -0,0 +1,230 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds how far into the future Next searches for a matching time, so that
+// impossible schedules (e.g. "0 0 0 30 2 *") do not loop forever.
+const maxSearchYears = 5
+
+// field describes the allowed values of a single cron field.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	seconds = field{name: "second", min: 0, max: 59}
+	minutes = field{name: "minute", min: 0, max: 59}
+	hours   = field{name: "hour", min: 0, max: 23}
+	days    = field{name: "day of month", min: 1, max: 31}
+	months  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	weekdays = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	seconds, minutes, hours, days, months, weekdays bitset
+
+	// Following standard cron semantics, if both the day of month and day of week are
+	// restricted, a time matches if either one matches.
+	daysRestricted, weekdaysRestricted bool
+}
+
+// Parse parses a cron expression. Both the standard 5 field format (minute, hour, day of month,
+// month, day of week) and the 6 field format with a leading seconds field are supported. Each
+// field may be a `*` (or `?`), a value, a range `a-b`, a step `*/n` or `a-b/n`, or a comma
+// separated list of these. Months and days of week may also be given by their 3 letter names.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5: //nolint:mnd // standard cron format.
+		fields = append([]string{"0"}, fields...)
+	case 6: //nolint:mnd // cron format with seconds.
+	default:
+		return nil, fmt.Errorf("cron expression %q must have 5 or 6 fields", expr)
+	}
+
+	var (
+		s   = &Schedule{}
+		err error
+	)
+	if s.seconds, _, err = parseField(fields[0], seconds); err != nil {
+		return nil, err
+	}
+	if s.minutes, _, err = parseField(fields[1], minutes); err != nil {
+		return nil, err
+	}
+	if s.hours, _, err = parseField(fields[2], hours); err != nil {
+		return nil, err
+	}
+	if s.days, s.daysRestricted, err = parseField(fields[3], days); err != nil {
+		return nil, err
+	}
+	if s.months, _, err = parseField(fields[4], months); err != nil {
+		return nil, err
+	}
+	if s.weekdays, s.weekdaysRestricted, err = parseField(fields[5], weekdays); err != nil {
+		return nil, err
+	}
+
+	// Sunday may be given as either 0 or 7.
+	if s.weekdays.has(7) { //nolint:mnd // sunday.
+		s.weekdays.set(0)
+	}
+	return s, nil
+}
+
+// MustParse parses a cron expression and panics if it is invalid.
+func MustParse(expr string) *Schedule {
+	s, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Next returns the first time strictly after t that matches the schedule, in t's location. The
+// zero time is returned if no matching time exists within the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	var (
+		loc       = t.Location()
+		yearLimit = t.Year() + maxSearchYears
+	)
+
+	// Start at the next whole second.
+	t = t.Truncate(time.Second).Add(time.Second)
+
+	for t.Year() <= yearLimit {
+		switch {
+		case !s.months.has(int(t.Month())):
+			t = advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+		case !s.dayMatches(t):
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+		case !s.hours.has(t.Hour()):
+			// Step in absolute time, since a local hour may not exist on a DST change.
+			t = t.Truncate(time.Minute).Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+		case !s.minutes.has(t.Minute()):
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case !s.seconds.has(t.Second()):
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// advance returns next if it is after t. Otherwise (i.e. the local midnight of next does not exist
+// due to a DST change), it steps t forward by an hour.
+func advance(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Truncate(time.Minute).Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+}
+
+// dayMatches returns true if the day of t matches the day of month and day of week fields.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dayMatch := s.days.has(t.Day())
+	weekdayMatch := s.weekdays.has(int(t.Weekday()))
+	if s.daysRestricted && s.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+// parseField parses a single cron field, returning the set of allowed values and whether the
+// field restricts the values at all (i.e. is not a `*`).
+func parseField(expr string, f field) (bitset, bool, error) {
+	var (
+		set        bitset
+		restricted = true
+	)
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		// Determine the range of the part.
+		lo, hi := f.min, f.max
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+			if !hasStep {
+				restricted = false
+			}
+			if f.max == weekdays.max {
+				hi = 6 // don't double count sunday.
+			}
+		case strings.Contains(rangeExpr, "-"):
+			loExpr, hiExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = parseValue(loExpr, f); err != nil {
+				return 0, false, err
+			}
+			if hi, err = parseValue(hiExpr, f); err != nil {
+				return 0, false, err
+			}
+			if lo > hi {
+				return 0, false, fmt.Errorf("invalid %s range %q", f.name, rangeExpr)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangeExpr, f); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			if hasStep {
+				hi = f.max // `a/n` is shorthand for `a-max/n`.
+			}
+		}
+
+		// Determine the step of the part.
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("invalid %s step %q", f.name, stepExpr)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set.set(v)
+		}
+	}
+	return set, restricted, nil
+}
+
+// parseValue parses a single value (number or name) of a cron field.
+func parseValue(expr string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", f.name, expr)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %d out of range [%d, %d]", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// bitset is a set of small non-negative integers.
+type bitset uint64
+
+func (b *bitset) set(v int) {
+	*b |= 1 << uint(v) //nolint:gosec // values are bounded by the field ranges.
+}
+
+func (b bitset) has(v int) bool {
+	return b&(1<<uint(v)) != 0 //nolint:gosec // values are bounded by the field ranges.
+}
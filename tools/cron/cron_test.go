@@ -0,0 +1,83 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/tools/cron"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			expr:     "0 */5 * * * *",
+			from:     time.Date(2024, 1, 1, 10, 2, 30, 0, time.UTC),
+			expected: time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC),
+		},
+		{
+			// 5 field expressions run on the minute.
+			expr:     "30 9 * * mon-fri",
+			from:     time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC), // a Friday
+			expected: time.Date(2024, 1, 8, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			expr:     "0 0 0 1 */3 *",
+			from:     time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Day of month and day of week are OR-ed when both are restricted.
+			expr:     "0 0 12 13 * 5",
+			from:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			expr:     "0 0 0 29 feb *",
+			from:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Evaluated in the schedule's timezone. 2am does not exist on the day of the DST
+			// change, so that day is skipped.
+			expr:     "0 0 2 * * *",
+			from:     time.Date(2024, 3, 9, 3, 0, 0, 0, ny),
+			expected: time.Date(2024, 3, 11, 2, 0, 0, 0, ny),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			require.Equal(t, tt.expected, cron.MustParse(tt.expr).Next(tt.from))
+		})
+	}
+}
+
+func TestNextImpossible(t *testing.T) {
+	next := cron.MustParse("0 0 0 30 2 *").Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, next.IsZero())
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",
+		"60 * * * * *",
+		"* * 24 * * *",
+		"* * * 0 * *",
+		"* * * * 13 *",
+		"* * * * * 8",
+		"*/0 * * * * *",
+		"5-1 * * * * *",
+		"* * * * foo *",
+	} {
+		_, err := cron.Parse(expr)
+		require.Error(t, err, expr)
+	}
+}
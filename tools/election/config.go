@@ -0,0 +1,118 @@
+package election
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/berachain/offchain-sdk/tools/store"
+)
+
+const (
+	BackendRedis    = "redis"
+	BackendFile     = "file"
+	BackendPostgres = "postgres"
+
+	defaultKey            = "offchain-sdk-leader"
+	defaultTTL            = 15 * time.Second
+	defaultPostgresDriver = "postgres"
+)
+
+// Config is the configuration for leader election. Leader election is disabled if no backend is
+// set, in which case every replica is considered the leader.
+type Config struct {
+	// Backend is the backend of the leader lock, one of "redis", "file" or "postgres".
+	Backend string
+
+	// Key names the leader lock, which must be the same for all replicas: the Redis key, or the
+	// key that the Postgres advisory lock ID is derived from. Defaults to "offchain-sdk-leader".
+	Key string
+
+	// TTL is how long the Redis lock is held for without being renewed. Defaults to 15s.
+	TTL time.Duration
+
+	// RenewInterval is how often the lock is acquired, or renewed while held. Defaults to a third
+	// of the TTL.
+	RenewInterval time.Duration
+
+	// RedisAddr is the address of Redis, for the "redis" backend.
+	RedisAddr        string
+	RedisClusterMode bool
+
+	// FilePath is the path of the lock file, for the "file" backend.
+	FilePath string
+
+	// PostgresDriver is the name of the registered `database/sql` driver for Postgres, e.g.
+	// "postgres" or "pgx", for the "postgres" backend. Defaults to "postgres".
+	PostgresDriver string
+	PostgresDSN    string
+}
+
+// Enabled returns whether leader election is enabled.
+func (c *Config) Enabled() bool {
+	return c.Backend != ""
+}
+
+// WithDefaults returns a copy of the config with any unset fields filled in with the defaults.
+func (c Config) WithDefaults() *Config {
+	if c.Key == "" {
+		c.Key = defaultKey
+	}
+	if c.TTL == 0 {
+		c.TTL = defaultTTL
+	}
+	if c.RenewInterval == 0 {
+		c.RenewInterval = c.TTL / 3 //nolint:mnd // renew well before the lock expires.
+	}
+	if c.PostgresDriver == "" {
+		c.PostgresDriver = defaultPostgresDriver
+	}
+	return &c
+}
+
+// NewLock creates the leader lock of the configured backend.
+func NewLock(cfg *Config) (Lock, error) {
+	switch cfg.Backend {
+	case BackendRedis:
+		if cfg.RedisAddr == "" {
+			return nil, errors.New("leader election: redis address must be set")
+		}
+		holder, err := newHolderID()
+		if err != nil {
+			return nil, err
+		}
+		client := store.NewRedisClient(cfg.RedisAddr, cfg.RedisClusterMode)
+		return NewRedisLock(client, cfg.Key, holder, cfg.TTL), nil
+	case BackendFile:
+		if cfg.FilePath == "" {
+			return nil, errors.New("leader election: file path must be set")
+		}
+		return NewFileLock(cfg.FilePath), nil
+	case BackendPostgres:
+		db, err := sql.Open(cfg.PostgresDriver, cfg.PostgresDSN)
+		if err != nil {
+			return nil, fmt.Errorf("leader election: %w", err)
+		}
+		return NewPostgresLock(db, cfg.Key), nil
+	default:
+		return nil, fmt.Errorf("leader election: unknown backend %q", cfg.Backend)
+	}
+}
+
+// newHolderID returns an ID that is unique to this replica, made up of the host name, the process
+// ID and a random suffix.
+func newHolderID() (string, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	suffix := make([]byte, 4) //nolint:mnd // 4 bytes is plenty to tell replicas apart.
+	if _, err = rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix)), nil
+}
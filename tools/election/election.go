@@ -0,0 +1,133 @@
+package election
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+)
+
+// releaseTimeout bounds releasing the lock when giving up leadership.
+const releaseTimeout = 5 * time.Second
+
+// Lock is a lock that is held by at most one replica at a time, which makes that replica the
+// leader.
+type Lock interface {
+	// TryAcquire acquires the lock without blocking, or renews it if it is already held, and
+	// returns whether the lock is held.
+	TryAcquire(ctx context.Context) (bool, error)
+
+	// Release releases the lock, if it is held.
+	Release(ctx context.Context) error
+}
+
+// Elector campaigns for leadership by periodically acquiring, or renewing, a lock.
+type Elector struct {
+	lock          Lock
+	renewInterval time.Duration
+	logger        log.Logger
+	leader        atomic.Bool
+}
+
+// NewElector creates a new elector that acquires or renews the given lock every renew interval.
+func NewElector(lock Lock, renewInterval time.Duration, logger log.Logger) *Elector {
+	return &Elector{
+		lock:          lock,
+		renewInterval: renewInterval,
+		logger:        logger.With("namespace", "election"),
+	}
+}
+
+// IsLeader returns whether the elector currently holds the lock.
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run campaigns for leadership until the context is cancelled, calling onElected when leadership
+// is gained and onDemoted when it is lost. Failing to renew the lock counts as losing leadership,
+// since another replica may acquire it in the meantime. The callbacks are called in the
+// background, one at a time, so that the lock keeps being renewed while they run. Once the context
+// is cancelled, leadership is given up and the lock is released, after onDemoted returns.
+func (e *Elector) Run(ctx context.Context, onElected, onDemoted func()) {
+	changed := make(chan struct{}, 1)
+	followed := make(chan struct{})
+	go func() {
+		defer close(followed)
+		e.follow(changed, onElected, onDemoted)
+	}()
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx, changed)
+
+		select {
+		case <-ctx.Done():
+			e.resign(changed, followed)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign acquires or renews the lock, notifying the given channel if leadership changed.
+func (e *Elector) campaign(ctx context.Context, changed chan<- struct{}) {
+	held, err := e.lock.TryAcquire(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		e.logger.Error("failed to acquire leader lock", "err", err)
+	}
+
+	switch {
+	case held && !e.leader.Load():
+		e.leader.Store(true)
+		e.logger.Info("👑 elected leader")
+	case !held && e.leader.Load():
+		e.leader.Store(false)
+		e.logger.Warn("lost leadership")
+	default:
+		return
+	}
+	select {
+	case changed <- struct{}{}:
+	default: // a change is already pending
+	}
+}
+
+// follow calls onElected or onDemoted whenever leadership changed, until the given channel is
+// closed, after which onDemoted is called if leading. Changes made while a callback runs are
+// coalesced, e.g. a replica that is demoted and elected again meanwhile is not notified.
+func (e *Elector) follow(changed <-chan struct{}, onElected, onDemoted func()) {
+	var elected bool
+	for range changed {
+		if leader := e.leader.Load(); leader != elected {
+			if elected = leader; elected {
+				onElected()
+			} else {
+				onDemoted()
+			}
+		}
+	}
+	if elected {
+		onDemoted()
+	}
+}
+
+// resign gives up leadership, if held, and releases the lock once onDemoted returns.
+func (e *Elector) resign(changed chan struct{}, followed <-chan struct{}) {
+	if e.leader.Swap(false) {
+		e.logger.Info("resigning leadership")
+	}
+	close(changed)
+	<-followed
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := e.lock.Release(ctx); err != nil {
+		e.logger.Error("failed to release leader lock", "err", err)
+	}
+}
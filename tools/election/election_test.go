@@ -0,0 +1,148 @@
+package election_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/tools/election"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLock is a lock whose acquisition results are set by the test.
+type fakeLock struct {
+	mu       sync.Mutex
+	held     bool
+	err      error
+	released bool
+	acquires int
+}
+
+func (l *fakeLock) set(held bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held, l.err = held, err
+}
+
+func (l *fakeLock) TryAcquire(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.acquires++
+	return l.held, l.err
+}
+
+func (l *fakeLock) acquired() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.acquires
+}
+
+func (l *fakeLock) Release(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.released = true
+	return nil
+}
+
+func TestElector(t *testing.T) {
+	lock := &fakeLock{}
+	elector := election.NewElector(lock, 10*time.Millisecond, log.NewNopLogger())
+
+	events := make(chan string, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx, func() { events <- "elected" }, func() { events <- "demoted" })
+	}()
+
+	// Not leading while the lock is taken.
+	time.Sleep(30 * time.Millisecond)
+	require.False(t, elector.IsLeader())
+	require.Empty(t, events)
+
+	// Elected once the lock is acquired, and only notified once while it is renewed.
+	lock.set(true, nil)
+	require.Equal(t, "elected", <-events)
+	time.Sleep(30 * time.Millisecond)
+	require.True(t, elector.IsLeader())
+	require.Empty(t, events)
+
+	// Failing to renew the lock demotes.
+	lock.set(false, errors.New("connection lost"))
+	require.Equal(t, "demoted", <-events)
+	require.False(t, elector.IsLeader())
+
+	// Resigns and releases the lock on stop.
+	lock.set(true, nil)
+	require.Equal(t, "elected", <-events)
+	cancel()
+	<-done
+	require.Equal(t, "demoted", <-events)
+	require.False(t, elector.IsLeader())
+	require.True(t, lock.released)
+}
+
+func TestElectorRenewsDuringCallbacks(t *testing.T) {
+	lock := &fakeLock{held: true}
+	elector := election.NewElector(lock, 10*time.Millisecond, log.NewNopLogger())
+
+	elected, unblock := make(chan struct{}), make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx, func() {
+			close(elected)
+			<-unblock
+		}, func() {})
+	}()
+
+	// The lock keeps being renewed while the slow onElected runs.
+	<-elected
+	renewals := lock.acquired()
+	time.Sleep(50 * time.Millisecond)
+	require.Greater(t, lock.acquired(), renewals+1)
+
+	// The lock is only released once the callbacks return.
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	lock.mu.Lock()
+	require.False(t, lock.released)
+	lock.mu.Unlock()
+	close(unblock)
+	<-done
+	require.True(t, lock.released)
+}
+
+func TestFileLock(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		path  = filepath.Join(t.TempDir(), "leader.lock")
+		lockA = election.NewFileLock(path)
+		lockB = election.NewFileLock(path)
+	)
+
+	held, err := lockA.TryAcquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	// Renewing a held lock keeps it, while others cannot acquire it.
+	held, err = lockA.TryAcquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+	held, err = lockB.TryAcquire(ctx)
+	require.NoError(t, err)
+	require.False(t, held)
+
+	// Once released, others can acquire it.
+	require.NoError(t, lockA.Release(ctx))
+	held, err = lockB.TryAcquire(ctx)
+	require.NoError(t, err)
+	require.True(t, held)
+	require.NoError(t, lockB.Release(ctx))
+}
@@ -0,0 +1,28 @@
+package election
+
+import (
+	"context"
+
+	"github.com/gofrs/flock"
+)
+
+// FileLock is an exclusive lock on a file, for replicas that run on the same host or share a file
+// system that supports locks. The lock is freed up by the OS if its holder dies.
+type FileLock struct {
+	flock *flock.Flock
+}
+
+// NewFileLock creates a lock on the file at the given path, which is created if it does not exist.
+func NewFileLock(path string) *FileLock {
+	return &FileLock{flock: flock.New(path)}
+}
+
+// TryAcquire implements Lock.
+func (fl *FileLock) TryAcquire(context.Context) (bool, error) {
+	return fl.flock.TryLock()
+}
+
+// Release implements Lock.
+func (fl *FileLock) Release(context.Context) error {
+	return fl.flock.Unlock()
+}
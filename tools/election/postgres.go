@@ -0,0 +1,76 @@
+package election
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"sync"
+)
+
+// PostgresLock is a session-level Postgres advisory lock, held on a dedicated connection so that
+// the lock is freed up by Postgres if the connection (or its holder) dies.
+type PostgresLock struct {
+	db *sql.DB
+	id int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewPostgresLock creates an advisory lock whose ID is derived from the given key. The database
+// must use a Postgres driver.
+func NewPostgresLock(db *sql.DB, key string) *PostgresLock {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return &PostgresLock{
+		db: db,
+		id: int64(h.Sum64()), //nolint:gosec // the ID only needs to be derived consistently.
+	}
+}
+
+// TryAcquire implements Lock. A held lock is renewed by checking that its connection is alive.
+func (pl *PostgresLock) TryAcquire(ctx context.Context) (bool, error) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if pl.conn != nil {
+		if err := pl.conn.PingContext(ctx); err != nil {
+			return false, errors.Join(err, pl.closeConn())
+		}
+		return true, nil
+	}
+
+	conn, err := pl.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var acquired bool
+	if err = conn.QueryRowContext(
+		ctx, "SELECT pg_try_advisory_lock($1)", pl.id,
+	).Scan(&acquired); err != nil || !acquired {
+		return false, errors.Join(err, conn.Close())
+	}
+
+	pl.conn = conn
+	return true, nil
+}
+
+// Release implements Lock.
+func (pl *PostgresLock) Release(ctx context.Context) error {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if pl.conn == nil {
+		return nil
+	}
+	_, err := pl.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", pl.id)
+	return errors.Join(err, pl.closeConn())
+}
+
+// closeConn closes the connection holding the lock, which frees up the lock.
+func (pl *PostgresLock) closeConn() error {
+	err := pl.conn.Close()
+	pl.conn = nil
+	return err
+}
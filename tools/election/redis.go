@@ -0,0 +1,64 @@
+package election
+
+import (
+	"context"
+	"time"
+
+	"github.com/berachain/offchain-sdk/tools/store"
+)
+
+const (
+	// renewScript extends the expiry of the lock, only if it is held by the given holder.
+	renewScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`
+
+	// releaseScript deletes the lock, only if it is held by the given holder.
+	releaseScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
+)
+
+// RedisLock is a lock on a Redis key, which expires unless it is renewed within its TTL so that
+// the lock is freed up if its holder dies.
+type RedisLock struct {
+	client store.RedisClient
+	key    string
+	holder string
+	ttl    time.Duration
+}
+
+// NewRedisLock creates a lock on the given Redis key, which is held by the given holder ID. The
+// holder ID must be unique among the replicas.
+func NewRedisLock(client store.RedisClient, key, holder string, ttl time.Duration) *RedisLock {
+	return &RedisLock{
+		client: client,
+		key:    key,
+		holder: holder,
+		ttl:    ttl,
+	}
+}
+
+// TryAcquire implements Lock.
+func (rl *RedisLock) TryAcquire(ctx context.Context) (bool, error) {
+	acquired, err := rl.client.SetNX(ctx, rl.key, rl.holder, rl.ttl).Result()
+	if err != nil || acquired {
+		return acquired, err
+	}
+
+	// The lock is taken, renew it in case it is held by us.
+	renewed, err := rl.client.Eval(
+		ctx, renewScript, []string{rl.key}, rl.holder, rl.ttl.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return false, err
+	}
+	return renewed == 1, nil
+}
+
+// Release implements Lock.
+func (rl *RedisLock) Release(ctx context.Context) error {
+	return rl.client.Eval(ctx, releaseScript, []string{rl.key}, rl.holder).Err()
+}
@@ -47,6 +47,12 @@ func NewContext(
 	}
 }
 
+// WithContext returns a copy of the sdk context that wraps the given context instead, e.g. one
+// derived from the sdk context with a deadline.
+func (c *Context) WithContext(ctx context.Context) *Context {
+	return NewContext(ctx, c.chain, c.logger, c.db, c.metrics)
+}
+
 func (c *Context) Chain() eth.Client {
 	return c.chain
 }
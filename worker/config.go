@@ -1,6 +1,11 @@
 package worker
 
-import "github.com/alitto/pond"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alitto/pond"
+)
 
 // PoolConfig is the configuration for a pool.
 type PoolConfig struct {
@@ -34,6 +39,66 @@ func DefaultPoolConfig() *PoolConfig {
 	}
 }
 
+// WithDefaults returns a copy of the config where every unset (zero-valued) field is filled in
+// from the given defaults.
+func (c PoolConfig) WithDefaults(defaults *PoolConfig) *PoolConfig {
+	if c.Name == "" {
+		c.Name = defaults.Name
+	}
+	if c.PrometheusPrefix == "" {
+		c.PrometheusPrefix = defaults.PrometheusPrefix
+	}
+	if c.MinWorkers == 0 {
+		c.MinWorkers = defaults.MinWorkers
+	}
+	if c.MaxWorkers == 0 {
+		c.MaxWorkers = defaults.MaxWorkers
+	}
+	if c.ResizingStrategy == "" {
+		c.ResizingStrategy = defaults.ResizingStrategy
+	}
+	if c.MaxQueuedJobs == 0 {
+		c.MaxQueuedJobs = defaults.MaxQueuedJobs
+	}
+	return &c
+}
+
+// Validate returns an error if the config cannot be used to build a pool.
+func (c *PoolConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("pool name must be set")
+	}
+	if c.PrometheusPrefix == "" {
+		return fmt.Errorf("pool %s: prometheus prefix must be set", c.Name)
+	}
+	if c.MaxWorkers == 0 {
+		return fmt.Errorf("pool %s: max workers must be greater than 0", c.Name)
+	}
+	if c.MinWorkers > c.MaxWorkers {
+		return fmt.Errorf(
+			"pool %s: min workers (%d) must not exceed max workers (%d)",
+			c.Name, c.MinWorkers, c.MaxWorkers,
+		)
+	}
+	if !isValidResizer(c.ResizingStrategy) {
+		return fmt.Errorf(
+			"pool %s: invalid resizing strategy %q, must be one of eager, lazy or balanced",
+			c.Name, c.ResizingStrategy,
+		)
+	}
+	return nil
+}
+
+// isValidResizer returns true if the given name corresponds to a pond resizer.
+func isValidResizer(name string) bool {
+	switch name {
+	case "eager", "lazy", "balanced":
+		return true
+	default:
+		return false
+	}
+}
+
 // resizerFromString returns a pond resizer for the given name.
 func resizerFromString(name string) pond.ResizingStrategy {
 	switch name {
@@ -0,0 +1,38 @@
+package worker_test
+
+import (
+	"testing"
+
+	"github.com/berachain/offchain-sdk/worker"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPoolConfigWithDefaults tests that only unset fields are filled in from the defaults.
+func TestPoolConfigWithDefaults(t *testing.T) {
+	cfg := worker.PoolConfig{MaxWorkers: 64, ResizingStrategy: "lazy"}.WithDefaults(
+		worker.DefaultPoolConfig(),
+	)
+
+	require.Equal(t, "default", cfg.Name)
+	require.Equal(t, "default", cfg.PrometheusPrefix)
+	require.Equal(t, uint16(4), cfg.MinWorkers)
+	require.Equal(t, uint16(64), cfg.MaxWorkers)
+	require.Equal(t, "lazy", cfg.ResizingStrategy)
+	require.Equal(t, uint16(100), cfg.MaxQueuedJobs)
+	require.NoError(t, cfg.Validate())
+}
+
+// TestPoolConfigValidate tests that invalid pool configs are rejected.
+func TestPoolConfigValidate(t *testing.T) {
+	cfg := worker.DefaultPoolConfig()
+	cfg.MaxWorkers = 0
+	require.ErrorContains(t, cfg.Validate(), "max workers must be greater than 0")
+
+	cfg = worker.DefaultPoolConfig()
+	cfg.MinWorkers = 64
+	require.ErrorContains(t, cfg.Validate(), "must not exceed max workers")
+
+	cfg = worker.DefaultPoolConfig()
+	cfg.ResizingStrategy = "greedy"
+	require.ErrorContains(t, cfg.Validate(), "invalid resizing strategy")
+}
@@ -0,0 +1,76 @@
+package worker
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// LimitedPool wraps a pool, capping the number of tasks submitted through it that can be in flight
+// (queued or running) at once. Submitting blocks until a slot frees up.
+type LimitedPool struct {
+	pool     *Pool
+	slots    chan struct{}
+	inFlight prometheus.Collector
+}
+
+// NewLimitedPool creates a new limited pool on top of the given pool.
+func NewLimitedPool(pool *Pool, maxConcurrency uint16, promPrefix string) *LimitedPool {
+	lp := &LimitedPool{
+		pool:  pool,
+		slots: make(chan struct{}, maxConcurrency),
+	}
+	lp.inFlight = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: promPrefix + "_tasks_in_flight",
+			Help: "Number of tasks either queued or running",
+		},
+		func() float64 {
+			return float64(lp.InFlight())
+		})
+	prometheus.MustRegister(lp.inFlight)
+	return lp
+}
+
+// Close unregisters the metrics of the limited pool, so that a limited pool with the same prefix
+// can be created once this one is no longer used. It does not stop the underlying pool.
+func (lp *LimitedPool) Close() {
+	prometheus.Unregister(lp.inFlight)
+}
+
+// Submit submits a task to the underlying pool once a slot is available.
+func (lp *LimitedPool) Submit(task func()) {
+	lp.slots <- struct{}{}
+	lp.pool.Submit(lp.release(task))
+}
+
+// SubmitAndWait submits a task to the underlying pool once a slot is available and waits for it
+// to complete.
+func (lp *LimitedPool) SubmitAndWait(task func()) {
+	lp.slots <- struct{}{}
+	lp.pool.SubmitAndWait(lp.release(task))
+}
+
+// TrySubmit submits a task to the underlying pool if a slot is available and the pool accepts it
+// without blocking, returning whether it was submitted.
+func (lp *LimitedPool) TrySubmit(task func()) bool {
+	select {
+	case lp.slots <- struct{}{}:
+	default:
+		return false
+	}
+	if !lp.pool.TrySubmit(lp.release(task)) {
+		<-lp.slots
+		return false
+	}
+	return true
+}
+
+// InFlight returns the number of tasks either queued or running.
+func (lp *LimitedPool) InFlight() int {
+	return len(lp.slots)
+}
+
+// release wraps the task to free up its slot once done, even if the task panics.
+func (lp *LimitedPool) release(task func()) func() {
+	return func() {
+		defer func() { <-lp.slots }()
+		task()
+	}
+}
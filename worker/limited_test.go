@@ -0,0 +1,72 @@
+package worker_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/worker"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimitedPool tests that the limited pool never runs more tasks at once than its limit, even
+// if the underlying pool has spare workers.
+func TestLimitedPool(t *testing.T) {
+	cfg := worker.DefaultPoolConfig()
+	cfg.Name = "limited-test"
+	cfg.PrometheusPrefix = "limited_test"
+	pool := worker.NewPool(context.Background(), log.NewLogger(io.Discard, "test-runner"), cfg)
+	defer pool.StopAndWait()
+
+	var (
+		limited             = worker.NewLimitedPool(pool, 2, "limited_test_job")
+		running, maxRunning atomic.Int32
+		wg                  sync.WaitGroup
+	)
+	for range 10 {
+		wg.Add(1)
+		limited.Submit(func() {
+			defer wg.Done()
+			curr := running.Add(1)
+			for {
+				prev := maxRunning.Load()
+				if curr <= prev || maxRunning.CompareAndSwap(prev, curr) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		})
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), maxRunning.Load())
+	require.Eventually(t, func() bool {
+		return limited.InFlight() == 0
+	}, time.Second, time.Millisecond)
+}
+
+// TestLimitedPoolTrySubmit tests that submitting without blocking fails while all slots are taken.
+func TestLimitedPoolTrySubmit(t *testing.T) {
+	cfg := worker.DefaultPoolConfig()
+	cfg.Name = "limited-try-test"
+	cfg.PrometheusPrefix = "limited_try_test"
+	pool := worker.NewPool(context.Background(), log.NewLogger(io.Discard, "test-runner"), cfg)
+	defer pool.StopAndWait()
+
+	limited := worker.NewLimitedPool(pool, 1, "limited_try_test_job")
+	defer limited.Close()
+
+	unblock := make(chan struct{})
+	require.True(t, limited.TrySubmit(func() { <-unblock }))
+	require.False(t, limited.TrySubmit(func() {}))
+
+	close(unblock)
+	require.Eventually(t, func() bool {
+		return limited.TrySubmit(func() {})
+	}, time.Second, time.Millisecond)
+}
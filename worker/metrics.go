@@ -3,60 +3,73 @@ package worker
 import "github.com/prometheus/client_golang/prometheus"
 
 func (p *Pool) setupMetrics(prefix string) {
-	prometheus.MustRegister(prometheus.NewGaugeFunc(
-		prometheus.GaugeOpts{
-			Name: prefix + "_pool_workers_running",
-			Help: "Number of running worker goroutines",
-		},
-		func() float64 {
-			return float64(p.RunningWorkers())
-		}))
-	prometheus.MustRegister(prometheus.NewGaugeFunc(
-		prometheus.GaugeOpts{
-			Name: prefix + "_pool_workers_idle",
-			Help: "Number of idle worker goroutines",
-		},
-		func() float64 {
-			return float64(p.IdleWorkers())
-		}))
-	prometheus.MustRegister(prometheus.NewCounterFunc(
-		prometheus.CounterOpts{
-			Name: prefix + "_pool_tasks_submitted_total",
-			Help: "Number of tasks submitted",
-		},
-		func() float64 {
-			return float64(p.SubmittedTasks())
-		}))
-	prometheus.MustRegister(prometheus.NewGaugeFunc(
-		prometheus.GaugeOpts{
-			Name: prefix + "_pool_tasks_waiting_total",
-			Help: "Number of tasks waiting in the queue",
-		},
-		func() float64 {
-			return float64(p.WaitingTasks())
-		}))
-	prometheus.MustRegister(prometheus.NewCounterFunc(
-		prometheus.CounterOpts{
-			Name: prefix + "_pool_tasks_successful_total",
-			Help: "Number of tasks that completed successfully",
-		},
-		func() float64 {
-			return float64(p.SuccessfulTasks())
-		}))
-	prometheus.MustRegister(prometheus.NewCounterFunc(
-		prometheus.CounterOpts{
-			Name: prefix + "_pool_tasks_failed_total",
-			Help: "Number of tasks that completed with panic",
-		},
-		func() float64 {
-			return float64(p.FailedTasks())
-		}))
-	prometheus.MustRegister(prometheus.NewCounterFunc(
-		prometheus.CounterOpts{
-			Name: prefix + "_pool_tasks_completed_total",
-			Help: "Number of tasks that completed either successfully or with panic",
-		},
-		func() float64 {
-			return float64(p.CompletedTasks())
-		}))
+	p.collectors = []prometheus.Collector{
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: prefix + "_pool_workers_running",
+				Help: "Number of running worker goroutines",
+			},
+			func() float64 {
+				return float64(p.RunningWorkers())
+			}),
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: prefix + "_pool_workers_idle",
+				Help: "Number of idle worker goroutines",
+			},
+			func() float64 {
+				return float64(p.IdleWorkers())
+			}),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name: prefix + "_pool_tasks_submitted_total",
+				Help: "Number of tasks submitted",
+			},
+			func() float64 {
+				return float64(p.SubmittedTasks())
+			}),
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name: prefix + "_pool_tasks_waiting_total",
+				Help: "Number of tasks waiting in the queue",
+			},
+			func() float64 {
+				return float64(p.WaitingTasks())
+			}),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name: prefix + "_pool_tasks_successful_total",
+				Help: "Number of tasks that completed successfully",
+			},
+			func() float64 {
+				return float64(p.SuccessfulTasks())
+			}),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name: prefix + "_pool_tasks_failed_total",
+				Help: "Number of tasks that completed with panic",
+			},
+			func() float64 {
+				return float64(p.FailedTasks())
+			}),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name: prefix + "_pool_tasks_completed_total",
+				Help: "Number of tasks that completed either successfully or with panic",
+			},
+			func() float64 {
+				return float64(p.CompletedTasks())
+			}),
+	}
+	for _, collector := range p.collectors {
+		prometheus.MustRegister(collector)
+	}
+}
+
+// teardownMetrics unregisters the metrics of the pool, so that a pool with the same prefix can be
+// created once this one is stopped.
+func (p *Pool) teardownMetrics() {
+	for _, collector := range p.collectors {
+		prometheus.Unregister(collector)
+	}
 }
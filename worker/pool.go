@@ -5,12 +5,14 @@ import (
 
 	"github.com/alitto/pond"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // and other functionality to the pool.
 type Pool struct {
-	name   string
-	logger log.Logger
+	name       string
+	logger     log.Logger
+	collectors []prometheus.Collector
 	*pond.WorkerPool
 }
 
@@ -49,6 +51,7 @@ func (p *Pool) StopAndWait() {
 	)
 	defer p.Logger().Info("workers finished")
 	p.WorkerPool.StopAndWait()
+	p.teardownMetrics()
 }
 
 // Stop stops the pool without waiting for all workers to finish. NOTE: Tasks being executed by
@@ -58,4 +61,5 @@ func (p *Pool) Stop() {
 	p.Logger().Info("stopping worker pool")
 	defer p.Logger().Info("workers halted")
 	p.WorkerPool.Stop()
+	p.teardownMetrics()
 }
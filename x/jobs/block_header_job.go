@@ -10,24 +10,24 @@ import (
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// Compile time check to ensure that BlockHeaderWatcher implements job.BlockHeaderSub, and
-// optionally the basic job's Setup and Teardown methods.
+// Compile time check to ensure that BlockHeaderWatcher implements job.BlockHeaderSub. It is
+// checkpointed itself. The optional methods of the basic job are forwarded, see job.Forwarder.
 var (
 	_ job.BlockHeaderSub = (*BlockHeaderWatcher)(nil)
-	_ job.HasSetup       = (*BlockHeaderWatcher)(nil)
-	_ job.HasTeardown    = (*BlockHeaderWatcher)(nil)
+	_ job.Checkpointed   = (*BlockHeaderWatcher)(nil)
 )
 
 // BlockHeaderWatcher allows you to subscribe a basic job to a block header event.
 type BlockHeaderWatcher struct {
-	job.Basic
-	sub ethereum.Subscription
+	job.Forwarder
+	sub         ethereum.Subscription
+	checkpoints headerCheckpointer
 }
 
 // NewBlockHeaderWatcher creates a new BlockHeaderWatcher.
 func NewBlockHeaderWatcher(basic job.Basic) *BlockHeaderWatcher {
 	return &BlockHeaderWatcher{
-		Basic: basic,
+		Forwarder: job.Forward(basic),
 	}
 }
 
@@ -35,7 +35,7 @@ func (w *BlockHeaderWatcher) Subscribe(
 	ctx context.Context,
 ) (ethereum.Subscription, chan *coretypes.Header, error) {
 	sCtx := sdk.UnwrapContext(ctx)
-	headerCh, sub, err := sCtx.Chain().SubscribeNewHead(sCtx)
+	sub, headerCh, err := w.checkpoints.subscribe(sCtx, sCtx.Chain())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -49,16 +49,13 @@ func (w *BlockHeaderWatcher) Unsubscribe(context.Context) {
 	}
 }
 
-func (w *BlockHeaderWatcher) Setup(ctx context.Context) error {
-	if setupJob, ok := w.Basic.(job.HasSetup); ok {
-		return setupJob.Setup(ctx)
-	}
-	return nil
+// CheckpointOf implements job.Checkpointed, the checkpoint of a header is its height.
+func (w *BlockHeaderWatcher) CheckpointOf(input any) (job.Checkpoint, bool) {
+	return w.checkpoints.checkpointOf(input)
 }
 
-func (w *BlockHeaderWatcher) Teardown() error {
-	if setupJob, ok := w.Basic.(job.HasTeardown); ok {
-		return setupJob.Teardown()
-	}
+// RestoreCheckpoint implements job.Checkpointed.
+func (w *BlockHeaderWatcher) RestoreCheckpoint(_ context.Context, cp *job.Checkpoint) error {
+	w.checkpoints.restore(cp)
 	return nil
 }
@@ -0,0 +1,280 @@
+package jobs
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/job"
+
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// defaultBackfillRange is the default maximum number of blocks whose logs are queried at once.
+const defaultBackfillRange = 10000
+
+// BackfillConfig configures a subscription to logs to first backfill the logs since a start block,
+// so that logs emitted before it subscribed are delivered too.
+type BackfillConfig struct {
+	// FromBlock is the block to backfill logs from. The job's checkpoint, if any, takes precedence.
+	FromBlock uint64
+
+	// MaxRange is the maximum number of blocks whose logs are queried at once. The range is halved
+	// whenever the RPC rejects a query for its range or number of logs, and doubled back up to the
+	// maximum after each successful query. Defaults to 10000.
+	MaxRange uint64
+}
+
+// logCheckpointer resumes a subscription to logs from the job's checkpoint or backfill start
+// block, so that no logs are missed across restarts and resubscriptions.
+type logCheckpointer struct {
+	mu sync.Mutex
+	// enabled is whether the job is checkpointed, i.e. its checkpoint was restored.
+	enabled bool
+	// backfill is the backfill config, if the logs since a start block are backfilled.
+	backfill *BackfillConfig
+	// last is the position of the last log delivered, or of the restored checkpoint, if any.
+	last *job.Checkpoint
+}
+
+// checkpointOf returns the position of the given log. A removed log rolls the checkpoint back to
+// the end of the block before its own, so that the logs of its block are delivered again.
+func (lc *logCheckpointer) checkpointOf(input any) (job.Checkpoint, bool) {
+	log, ok := input.(coretypes.Log)
+	if !ok {
+		return job.Checkpoint{}, false
+	}
+	if log.Removed {
+		return rolledBack(log)
+	}
+	return job.Checkpoint{BlockNumber: log.BlockNumber, LogIndex: log.Index}, true
+}
+
+// rolledBack returns the checkpoint at the end of the block before the given log's, or false if
+// the log is in the genesis block.
+func rolledBack(log coretypes.Log) (job.Checkpoint, bool) {
+	if log.BlockNumber == 0 {
+		return job.Checkpoint{}, false
+	}
+	return job.Checkpoint{BlockNumber: log.BlockNumber - 1, LogIndex: math.MaxUint}, true
+}
+
+// restore resumes the subscription from the given checkpoint, if any.
+func (lc *logCheckpointer) restore(cp *job.Checkpoint) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.enabled, lc.last = true, cp
+}
+
+// setBackfill backfills the logs since the configured start block when subscribing, unless the
+// subscription resumes from a later position.
+func (lc *logCheckpointer) setBackfill(cfg BackfillConfig) {
+	if cfg.MaxRange == 0 {
+		cfg.MaxRange = defaultBackfillRange
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.backfill = &cfg
+}
+
+// subscribe subscribes to the logs of the given query. If the job is checkpointed or backfilled,
+// the logs since the last delivered log (or the backfill start block) are first backfilled up to
+// the head, and logs that were already delivered are skipped. Since the live subscription starts
+// before the backfill, the logs of the blocks after the backfilled head are delivered from it with
+// no gap in between.
+// If no websocket connection is available, the chain is polled for logs instead.
+func (lc *logCheckpointer) subscribe(
+	ctx context.Context, chain eth.Client, query ethereum.FilterQuery,
+) (ethereum.Subscription, chan coretypes.Log, error) {
+	lc.mu.Lock()
+	tracked, from, backfill := lc.enabled || lc.backfill != nil, lc.backfillFrom(), lc.backfill
+	lc.mu.Unlock()
+
+	ch := make(chan coretypes.Log)
+	if !tracked {
+		sub, err := subscribeFilterLogs(ctx, chain, query, ch)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sub, ch, nil
+	}
+
+	// Subscribe before backfilling, so that no logs are missed in between.
+	live := make(chan coretypes.Log)
+	liveSub, err := subscribeFilterLogs(ctx, chain, query, live)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxRange := uint64(defaultBackfillRange)
+	if backfill != nil {
+		maxRange = backfill.MaxRange
+	}
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		defer liveSub.Unsubscribe()
+		if from != nil {
+			ok, err := backfillLogs(ctx, chain, query, *from, maxRange,
+				func(log coretypes.Log) bool { return lc.deliver(log, ch, quit) },
+			)
+			if err != nil || !ok {
+				return err
+			}
+		}
+
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err := <-liveSub.Err():
+				return err
+			case log := <-live:
+				if !lc.deliver(log, ch, quit) {
+					return nil
+				}
+			}
+		}
+	})
+	return sub, ch, nil
+}
+
+// backfillFrom returns the block to backfill logs from, i.e. the block of the last delivered log,
+// or else the backfill start block, or nil if logs are not backfilled. It must be called with the
+// lock held.
+func (lc *logCheckpointer) backfillFrom() *uint64 {
+	switch {
+	case lc.last != nil:
+		return &lc.last.BlockNumber
+	case lc.backfill != nil:
+		return &lc.backfill.FromBlock
+	default:
+		return nil
+	}
+}
+
+// backfillLogs passes the logs of the given query from the given block up to the head to the
+// deliver function, querying at most maxRange blocks at once. The range is halved whenever a query
+// is rejected for its range, and grown back after each successful query. It returns false if
+// delivering a log failed instead, e.g. the subscription quit.
+func backfillLogs(
+	ctx context.Context, chain eth.Reader, query ethereum.FilterQuery,
+	from, maxRange uint64, deliver func(coretypes.Log) bool,
+) (bool, error) {
+	head, err := chain.BlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	limit := maxRange
+	for from <= head {
+		to := min(from+maxRange-1, head)
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		logs, err := chain.FilterLogs(ctx, query)
+		if err != nil {
+			if !eth.IsRangeLimitError(err) || maxRange == 1 {
+				return false, err
+			}
+			maxRange /= 2
+			continue
+		}
+
+		for _, log := range logs {
+			if !deliver(log) {
+				return false, nil
+			}
+		}
+		from = to + 1
+		maxRange = min(maxRange*2, limit)
+	}
+	return true, nil
+}
+
+// deliver sends the given log on the channel, unless it was already delivered. Removed logs are
+// always sent, since they revert logs that were delivered, and roll the last delivered position
+// back before their block, so that the logs that replace them (possibly at the same positions) are
+// delivered too. It returns false if the subscription quit instead.
+func (lc *logCheckpointer) deliver(
+	log coretypes.Log, ch chan<- coretypes.Log, quit <-chan struct{},
+) bool {
+	lc.mu.Lock()
+	last := lc.last
+	lc.mu.Unlock()
+	if !log.Removed && last != nil && last.Before(log.BlockNumber, log.Index) {
+		return true
+	}
+
+	select {
+	case <-quit:
+		return false
+	case ch <- log:
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	switch {
+	case !log.Removed:
+		lc.last = &job.Checkpoint{BlockNumber: log.BlockNumber, LogIndex: log.Index}
+	case lc.last != nil && lc.last.BlockNumber >= log.BlockNumber:
+		if cp, ok := rolledBack(log); ok {
+			lc.last = &cp
+		} else {
+			lc.last = nil
+		}
+	}
+	return true
+}
+
+// headerCheckpointer resumes a subscription to block headers from the job's checkpoint, so that
+// no block is missed across restarts and resubscriptions.
+type headerCheckpointer struct {
+	mu sync.Mutex
+	// enabled is whether the job is checkpointed, i.e. its checkpoint was restored.
+	enabled bool
+	// cursor follows the headers from the last header delivered, or from the restored checkpoint.
+	cursor eth.HeaderCursor
+}
+
+// checkpointOf returns the height of the given header.
+func (hc *headerCheckpointer) checkpointOf(input any) (job.Checkpoint, bool) {
+	header, ok := input.(*coretypes.Header)
+	if !ok || header == nil {
+		return job.Checkpoint{}, false
+	}
+	return job.Checkpoint{BlockNumber: header.Number.Uint64()}, true
+}
+
+// restore resumes the subscription from the given checkpoint, if any.
+func (hc *headerCheckpointer) restore(cp *job.Checkpoint) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.enabled = true
+	if cp != nil {
+		hc.cursor.Restore(cp.BlockNumber)
+	}
+}
+
+// subscribe subscribes to new block headers. If the job is checkpointed, the headers since the
+// last delivered header are fetched by number once a new head arrives, and headers that were
+// already delivered are skipped. If no websocket connection is available, the chain is polled for
+// new headers instead.
+func (hc *headerCheckpointer) subscribe(
+	ctx context.Context, chain eth.Client,
+) (ethereum.Subscription, chan *coretypes.Header, error) {
+	heads, headSub, err := subscribeNewHead(ctx, chain)
+	if err != nil {
+		return nil, nil, err
+	}
+	hc.mu.Lock()
+	enabled := hc.enabled
+	hc.mu.Unlock()
+	if !enabled {
+		return headSub, heads, nil
+	}
+
+	sub, ch := hc.cursor.Follow(ctx, chain, heads, headSub, nil)
+	return sub, ch, nil
+}
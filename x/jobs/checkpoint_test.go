@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillLogsRange(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("BlockNumber", mock.Anything).Return(uint64(99), nil)
+
+	// The RPC rejects ranges of more than 25 blocks, and returns a log per queried range.
+	var ranges [][2]uint64
+	client.On("FilterLogs", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, q ethereum.FilterQuery) ([]coretypes.Log, error) {
+			from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+			ranges = append(ranges, [2]uint64{from, to})
+			if to-from >= 25 {
+				return nil, errors.New("block range is too large")
+			}
+			return []coretypes.Log{{BlockNumber: from}}, nil
+		},
+	)
+
+	var delivered []uint64
+	ok, err := backfillLogs(context.Background(), client, ethereum.FilterQuery{}, 0, 40,
+		func(log coretypes.Log) bool {
+			delivered = append(delivered, log.BlockNumber)
+			return true
+		},
+	)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The range is halved on rejections and grown back after successes.
+	require.Equal(t, [][2]uint64{
+		{0, 39}, {0, 19}, {20, 59}, {20, 39}, {40, 79}, {40, 59}, {60, 99}, {60, 79}, {80, 99},
+	}, ranges)
+	require.Equal(t, []uint64{0, 20, 40, 60, 80}, delivered)
+}
+
+func TestBackfillLogsError(t *testing.T) {
+	client := new(mocks.Client)
+	client.On("BlockNumber", mock.Anything).Return(uint64(99), nil)
+	client.On("FilterLogs", mock.Anything, mock.Anything).Return(
+		nil, errors.New("connection refused"),
+	).Once()
+
+	// Other errors are returned, without splitting the range.
+	_, err := backfillLogs(context.Background(), client, ethereum.FilterQuery{}, 0, 40,
+		func(coretypes.Log) bool { return true },
+	)
+	require.ErrorContains(t, err, "connection refused")
+	client.AssertNumberOfCalls(t, "FilterLogs", 1)
+}
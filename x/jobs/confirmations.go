@@ -0,0 +1,346 @@
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// BlockTagSafe delivers logs once their block is safe.
+	BlockTagSafe = "safe"
+	// BlockTagFinalized delivers logs once their block is finalized.
+	BlockTagFinalized = "finalized"
+
+	defaultReorgWindow = 128
+)
+
+// ConfirmationConfig configures when logs are confirmed, and how long they are tracked for reorgs.
+type ConfirmationConfig struct {
+	// Confirmations is the number of blocks that must be built on top of a log's block before the
+	// log is delivered. With 0, logs are delivered as soon as their block is the head.
+	Confirmations uint64
+
+	// BlockTag, if set to "safe" or "finalized", delivers logs once their block is safe or
+	// finalized instead, regardless of the confirmations.
+	BlockTag string
+
+	// ReorgWindow is the number of blocks below the confirmed height that are tracked for reorgs.
+	// Delivered logs whose block drops out of the canonical chain within the window are reverted.
+	// Defaults to 128.
+	ReorgWindow uint64
+}
+
+// LogEvent is the input of a job subscribed with ConfirmedEthFilterSub: either a confirmed log, or
+// the revert of a log that was delivered before but whose block dropped out of the canonical
+// chain.
+type LogEvent struct {
+	Log      coretypes.Log
+	Reverted bool
+}
+
+// confirmationTracker buffers logs until they are confirmed, and keeps track of the hashes of the
+// canonical blocks to detect reorgs.
+type confirmationTracker struct {
+	cfg ConfirmationConfig
+
+	mu sync.Mutex
+	// hashes are the hashes of the recent canonical blocks, by height.
+	hashes map[uint64]common.Hash
+	// pending are the logs that are not confirmed yet, by block height.
+	pending map[uint64][]coretypes.Log
+	// delivered are the confirmed logs within the reorg window, by block height.
+	delivered map[uint64][]coretypes.Log
+	// confirmed is the height up to which logs were confirmed as of the last head, if started.
+	started   bool
+	confirmed uint64
+}
+
+// newConfirmationTracker creates a new tracker with the given config.
+func newConfirmationTracker(cfg ConfirmationConfig) *confirmationTracker {
+	if cfg.ReorgWindow == 0 {
+		cfg.ReorgWindow = defaultReorgWindow
+	}
+	return &confirmationTracker{
+		cfg:       cfg,
+		hashes:    make(map[uint64]common.Hash),
+		pending:   make(map[uint64][]coretypes.Log),
+		delivered: make(map[uint64][]coretypes.Log),
+	}
+}
+
+// validate ensures the block tag, if any, is supported.
+func (ct *confirmationTracker) validate() error {
+	switch ct.cfg.BlockTag {
+	case "", BlockTagSafe, BlockTagFinalized:
+		return nil
+	default:
+		return fmt.Errorf("unsupported block tag %q", ct.cfg.BlockTag)
+	}
+}
+
+// resumeFrom returns the block to backfill logs from when resubscribing, i.e. the block after the
+// confirmed height as of the last head, so that the logs emitted while the subscription was down
+// are not missed. It returns false on the first subscription.
+func (ct *confirmationTracker) resumeFrom() (uint64, bool) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return ct.confirmed + 1, ct.started
+}
+
+// addLog buffers the given log until it is confirmed. A removed log is dropped if it is pending,
+// or reverted if it was delivered.
+func (ct *confirmationTracker) addLog(log coretypes.Log) []LogEvent {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if log.Removed {
+		if removeLog(ct.pending, log) {
+			return nil
+		}
+		if removeLog(ct.delivered, log) {
+			return []LogEvent{{Log: log, Reverted: true}}
+		}
+		return nil
+	}
+
+	if !containsLog(ct.pending[log.BlockNumber], log) &&
+		!containsLog(ct.delivered[log.BlockNumber], log) {
+		ct.pending[log.BlockNumber] = append(ct.pending[log.BlockNumber], log)
+	}
+	return nil
+}
+
+// addHead processes a new head: it reverts the logs of the blocks that dropped out of the
+// canonical chain, then delivers the pending logs that are confirmed as of the head. The chain is
+// read before the changes are applied, so that the lock is not held across RPCs.
+func (ct *confirmationTracker) addHead(
+	ctx context.Context, reader eth.Reader, head *coretypes.Header,
+) ([]LogEvent, error) {
+	ct.mu.Lock()
+	tracked := maps.Clone(ct.hashes)
+	pending := slices.Collect(maps.Keys(ct.pending))
+	ct.mu.Unlock()
+
+	canonical, err := canonicalAncestors(ctx, reader, head, tracked)
+	if err != nil {
+		return nil, err
+	}
+	confirmed, err := ct.confirmedHeight(ctx, reader, head)
+	if err != nil {
+		return nil, err
+	}
+	for _, height := range pending {
+		if err = ct.fetchHash(ctx, reader, height, confirmed, tracked, canonical); err != nil {
+			return nil, err
+		}
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	events := ct.handleReorg(head.Number.Uint64(), canonical)
+	ct.hashes[head.Number.Uint64()] = head.Hash()
+
+	// Deliver the confirmed logs in order, dropping the ones of non-canonical blocks.
+	heights := make([]uint64, 0, len(ct.pending))
+	for height := range ct.pending {
+		if height <= confirmed {
+			heights = append(heights, height)
+		}
+	}
+	slices.Sort(heights)
+	for _, height := range heights {
+		hash, ok := canonical[height]
+		if !ok {
+			if hash, ok = ct.hashes[height]; !ok {
+				continue // the log arrived after the chain was read, so wait for the next head
+			}
+		}
+		ct.hashes[height] = hash
+
+		logs := ct.pending[height]
+		slices.SortFunc(logs, func(a, b coretypes.Log) int { return cmp.Compare(a.Index, b.Index) })
+		for _, log := range logs {
+			if log.BlockHash == hash {
+				events = append(events, LogEvent{Log: log})
+				ct.delivered[height] = append(ct.delivered[height], log)
+			}
+		}
+		delete(ct.pending, height)
+	}
+
+	ct.confirmed, ct.started = confirmed, true
+	ct.prune(confirmed)
+	return events, nil
+}
+
+// canonicalAncestors returns the hashes of the given head and of its ancestors, walking back
+// until reaching one of the tracked blocks that is canonical, or the lowest tracked height.
+func canonicalAncestors(
+	ctx context.Context, reader eth.Reader, head *coretypes.Header,
+	tracked map[uint64]common.Hash,
+) (map[uint64]common.Hash, error) {
+	height := head.Number.Uint64()
+	canonical := map[uint64]common.Hash{height: head.Hash()}
+	lowest := height
+	for trackedHeight := range tracked {
+		lowest = min(lowest, trackedHeight)
+	}
+
+	parent := head.ParentHash
+	for n := height - 1; n < height && n >= lowest; n-- {
+		canonical[n] = parent
+		if hash, ok := tracked[n]; ok && hash == parent {
+			break
+		}
+		header, err := reader.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, err
+		}
+		parent = header.ParentHash
+	}
+	return canonical, nil
+}
+
+// fetchHash fetches the hash of the canonical block at the given height of pending logs, if they
+// are confirmed and the block is neither tracked nor already known to be canonical.
+func (ct *confirmationTracker) fetchHash(
+	ctx context.Context, reader eth.Reader, height, confirmed uint64,
+	tracked, canonical map[uint64]common.Hash,
+) error {
+	if height > confirmed {
+		return nil
+	}
+	if _, ok := canonical[height]; ok {
+		return nil
+	}
+	if _, ok := tracked[height]; ok {
+		return nil
+	}
+	header, err := reader.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return err
+	}
+	canonical[height] = header.Hash()
+	return nil
+}
+
+// handleReorg reverts the logs of the tracked blocks that are no longer canonical as of the head
+// at the given height, i.e. the blocks above it and the ones that differ from its ancestors.
+func (ct *confirmationTracker) handleReorg(
+	height uint64, canonical map[uint64]common.Hash,
+) []LogEvent {
+	var events []LogEvent
+	for tracked, hash := range ct.hashes {
+		if tracked > height {
+			events = append(events, ct.revertBlock(tracked, common.Hash{})...)
+		} else if c, ok := canonical[tracked]; ok && c != hash {
+			events = append(events, ct.revertBlock(tracked, c)...)
+		}
+	}
+
+	// Revert the most recent logs first.
+	slices.SortFunc(events, func(a, b LogEvent) int {
+		if a.Log.BlockNumber != b.Log.BlockNumber {
+			return cmp.Compare(b.Log.BlockNumber, a.Log.BlockNumber)
+		}
+		return cmp.Compare(b.Log.Index, a.Log.Index)
+	})
+	return events
+}
+
+// revertBlock replaces the tracked block at the given height with the canonical one, or removes
+// it if the hash is empty. The logs of the replaced block are dropped if pending, or reverted if
+// delivered.
+func (ct *confirmationTracker) revertBlock(height uint64, canonical common.Hash) []LogEvent {
+	var events []LogEvent
+	ct.pending[height] = slices.DeleteFunc(ct.pending[height], func(log coretypes.Log) bool {
+		return log.BlockHash != canonical
+	})
+	ct.delivered[height] = slices.DeleteFunc(ct.delivered[height], func(log coretypes.Log) bool {
+		if log.BlockHash == canonical {
+			return false
+		}
+		log.Removed = true
+		events = append(events, LogEvent{Log: log, Reverted: true})
+		return true
+	})
+
+	if canonical == (common.Hash{}) {
+		delete(ct.hashes, height)
+	} else {
+		ct.hashes[height] = canonical
+	}
+	return events
+}
+
+// confirmedHeight returns the height up to which logs are confirmed as of the given head.
+func (ct *confirmationTracker) confirmedHeight(
+	ctx context.Context, reader eth.Reader, head *coretypes.Header,
+) (uint64, error) {
+	var tag rpc.BlockNumber
+	switch ct.cfg.BlockTag {
+	case BlockTagSafe:
+		tag = rpc.SafeBlockNumber
+	case BlockTagFinalized:
+		tag = rpc.FinalizedBlockNumber
+	default:
+		if head.Number.Uint64() < ct.cfg.Confirmations {
+			return 0, nil
+		}
+		return head.Number.Uint64() - ct.cfg.Confirmations, nil
+	}
+
+	header, err := reader.HeaderByNumber(ctx, big.NewInt(tag.Int64()))
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// prune stops tracking the blocks below the reorg window.
+func (ct *confirmationTracker) prune(confirmed uint64) {
+	if confirmed < ct.cfg.ReorgWindow {
+		return
+	}
+	floor := confirmed - ct.cfg.ReorgWindow
+	for height := range ct.hashes {
+		if height < floor {
+			delete(ct.hashes, height)
+		}
+	}
+	for height := range ct.delivered {
+		if height < floor {
+			delete(ct.delivered, height)
+		}
+	}
+}
+
+// containsLog returns whether the given logs contain the log.
+func containsLog(logs []coretypes.Log, log coretypes.Log) bool {
+	return slices.ContainsFunc(logs, func(other coretypes.Log) bool {
+		return other.BlockHash == log.BlockHash && other.Index == log.Index
+	})
+}
+
+// removeLog removes the log from the given logs by height, returning whether it was found.
+func removeLog(logsByHeight map[uint64][]coretypes.Log, log coretypes.Log) bool {
+	logs := logsByHeight[log.BlockNumber]
+	if !containsLog(logs, log) {
+		return false
+	}
+	logsByHeight[log.BlockNumber] = slices.DeleteFunc(logs, func(other coretypes.Log) bool {
+		return other.BlockHash == log.BlockHash && other.Index == log.Index
+	})
+	return true
+}
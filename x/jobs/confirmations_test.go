@@ -0,0 +1,175 @@
+package jobs
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain is a chain of headers, whose canonical blocks are served by a mock client.
+type testChain struct {
+	canonical       map[uint64]*coretypes.Header
+	safe, finalized uint64
+	client          *mocks.Client
+}
+
+// newTestChain creates a chain with only the genesis block.
+func newTestChain() *testChain {
+	c := &testChain{
+		canonical: map[uint64]*coretypes.Header{0: {Number: big.NewInt(0)}},
+		client:    new(mocks.Client),
+	}
+	c.client.On("HeaderByNumber", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, number *big.Int) (*coretypes.Header, error) {
+			switch number.Int64() {
+			case rpc.SafeBlockNumber.Int64():
+				return c.canonical[c.safe], nil
+			case rpc.FinalizedBlockNumber.Int64():
+				return c.canonical[c.finalized], nil
+			default:
+				return c.canonical[number.Uint64()], nil
+			}
+		},
+	)
+	return c
+}
+
+// extend builds n blocks on top of the canonical block at the given height, replacing the
+// canonical blocks above it. The fork tag distinguishes the blocks of different forks.
+func (c *testChain) extend(height uint64, n int, fork byte) *coretypes.Header {
+	parent := c.canonical[height]
+	for height := range c.canonical {
+		if height > parent.Number.Uint64() {
+			delete(c.canonical, height)
+		}
+	}
+	for range n {
+		parent = &coretypes.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Extra:      []byte{fork},
+		}
+		c.canonical[parent.Number.Uint64()] = parent
+	}
+	return parent
+}
+
+// logAt returns a log of the canonical block at the given height.
+func (c *testChain) logAt(height uint64, index uint) coretypes.Log {
+	return coretypes.Log{BlockNumber: height, BlockHash: c.canonical[height].Hash(), Index: index}
+}
+
+// addHeads adds the canonical heads from the given height up to the tip, returning the events.
+func addHeads(t *testing.T, ct *confirmationTracker, c *testChain, from uint64) []LogEvent {
+	var events []LogEvent
+	for height := from; c.canonical[height] != nil; height++ {
+		evs, err := ct.addHead(context.Background(), c.client, c.canonical[height])
+		require.NoError(t, err)
+		events = append(events, evs...)
+	}
+	return events
+}
+
+func TestConfirmationsDeliverConfirmedLogs(t *testing.T) {
+	c := newTestChain()
+	c.extend(0, 2, 0)
+	ct := newConfirmationTracker(ConfirmationConfig{Confirmations: 2})
+
+	require.Empty(t, ct.addLog(c.logAt(2, 1)))
+	require.Empty(t, ct.addLog(c.logAt(2, 0)))
+	require.Empty(t, ct.addLog(c.logAt(2, 0)), "duplicate logs are dropped")
+	require.Empty(t, addHeads(t, ct, c, 1))
+
+	// The logs are delivered in order once 2 blocks are built on top of their block.
+	c.extend(2, 2, 0)
+	events := addHeads(t, ct, c, 3)
+	require.Equal(t, []LogEvent{{Log: c.logAt(2, 0)}, {Log: c.logAt(2, 1)}}, events)
+}
+
+func TestConfirmationsSameHeightReorg(t *testing.T) {
+	c := newTestChain()
+	c.extend(0, 1, 0)
+	ct := newConfirmationTracker(ConfirmationConfig{})
+
+	delivered := c.logAt(1, 0)
+	ct.addLog(delivered)
+	require.Equal(t, []LogEvent{{Log: delivered}}, addHeads(t, ct, c, 1))
+
+	// A sibling of the head replaces it: the delivered log is reverted, the new one delivered.
+	c.extend(0, 1, 1)
+	replaced := c.logAt(1, 0)
+	ct.addLog(replaced)
+	delivered.Removed = true
+	require.Equal(t, []LogEvent{
+		{Log: delivered, Reverted: true}, {Log: replaced},
+	}, addHeads(t, ct, c, 1))
+}
+
+func TestConfirmationsDeepReorg(t *testing.T) {
+	c := newTestChain()
+	c.extend(0, 4, 0)
+	ct := newConfirmationTracker(ConfirmationConfig{Confirmations: 1})
+
+	logs := []coretypes.Log{c.logAt(1, 0), c.logAt(2, 0), c.logAt(3, 0)}
+	for _, log := range logs {
+		ct.addLog(log)
+	}
+	require.Len(t, addHeads(t, ct, c, 1), 3)
+
+	// A fork from block 1 replaces blocks 2 to 4: the logs of blocks 2 and 3 are reverted, most
+	// recent first, while the log of block 1 stays.
+	c.extend(1, 4, 1)
+	events := addHeads(t, ct, c, 5)
+	logs[2].Removed, logs[1].Removed = true, true
+	require.Equal(t, []LogEvent{
+		{Log: logs[2], Reverted: true}, {Log: logs[1], Reverted: true},
+	}, events)
+}
+
+func TestConfirmationsBlockTags(t *testing.T) {
+	for _, tag := range []string{BlockTagSafe, BlockTagFinalized} {
+		t.Run(tag, func(t *testing.T) {
+			c := newTestChain()
+			c.extend(0, 4, 0)
+			ct := newConfirmationTracker(ConfirmationConfig{Confirmations: 100, BlockTag: tag})
+			require.NoError(t, ct.validate())
+
+			ct.addLog(c.logAt(2, 0))
+			c.safe, c.finalized = 1, 1
+			require.Empty(t, addHeads(t, ct, c, 1))
+
+			// The log is delivered once its block is safe or finalized, regardless of the
+			// confirmations.
+			c.safe, c.finalized = 2, 2
+			require.Equal(t, []LogEvent{{Log: c.logAt(2, 0)}}, addHeads(t, ct, c, 4))
+		})
+	}
+
+	require.Error(t, newConfirmationTracker(ConfirmationConfig{BlockTag: "latest"}).validate())
+}
+
+func TestConfirmationsPrune(t *testing.T) {
+	c := newTestChain()
+	c.extend(0, 10, 0)
+	ct := newConfirmationTracker(ConfirmationConfig{Confirmations: 1, ReorgWindow: 2})
+
+	ct.addLog(c.logAt(2, 0))
+	require.Len(t, addHeads(t, ct, c, 1), 1)
+
+	// Only the blocks within the reorg window below the confirmed height 9 are still tracked.
+	for height := range ct.hashes {
+		require.GreaterOrEqual(t, height, uint64(7))
+	}
+	require.Empty(t, ct.delivered)
+
+	from, ok := ct.resumeFrom()
+	require.True(t, ok)
+	require.Equal(t, uint64(10), from)
+}
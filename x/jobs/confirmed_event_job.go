@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/berachain/offchain-sdk/job"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Compile time check to ensure that ConfirmedEthFilterSub implements job.Subscription. The
+// optional methods of the basic job are forwarded, see job.Forwarder.
+var (
+	_ job.Subscription[any] = (*ConfirmedEthFilterSub)(nil)
+)
+
+// ConfirmedEthFilterSub allows you to subscribe a basic job to the confirmed logs of an ethereum
+// filter query. The job is executed with a LogEvent for every log once it is confirmed, and with a
+// reverted LogEvent for every delivered log whose block drops out of the canonical chain, most
+// recent first.
+type ConfirmedEthFilterSub struct {
+	job.Forwarder
+	eventFilter   ethereum.FilterQuery
+	sub           ethereum.Subscription
+	confirmations *confirmationTracker
+}
+
+// NewConfirmedEthFilterSub creates a new ConfirmedEthFilterSub, confirming the logs of the given
+// filter query as configured.
+func NewConfirmedEthFilterSub(
+	basic job.Basic, eventFilter ethereum.FilterQuery, cfg ConfirmationConfig,
+) *ConfirmedEthFilterSub {
+	return &ConfirmedEthFilterSub{
+		Forwarder:     job.Forward(basic),
+		eventFilter:   eventFilter,
+		confirmations: newConfirmationTracker(cfg),
+	}
+}
+
+// Subscribe subscribes to the logs of the filter query and to new heads, delivering the logs as
+// they are confirmed or reverted. When resubscribing, the logs emitted since the last confirmed
+// height are backfilled first.
+func (j *ConfirmedEthFilterSub) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan any, error) {
+	if err := j.confirmations.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	chain := sdk.UnwrapContext(ctx).Chain()
+	logs := make(chan coretypes.Log)
+	logSub, err := subscribeFilterLogs(ctx, chain, j.eventFilter, logs)
+	if err != nil {
+		return nil, nil, err
+	}
+	heads, headSub, err := subscribeNewHead(ctx, chain)
+	if err != nil {
+		logSub.Unsubscribe()
+		return nil, nil, err
+	}
+
+	ch := make(chan any)
+	deliver := func(events []LogEvent, quit <-chan struct{}) bool {
+		for _, ev := range events {
+			select {
+			case <-quit:
+				return false
+			case ch <- ev:
+			}
+		}
+		return true
+	}
+	j.sub = event.NewSubscription(func(quit <-chan struct{}) error {
+		defer logSub.Unsubscribe()
+		defer headSub.Unsubscribe()
+		if from, ok := j.confirmations.resumeFrom(); ok {
+			if _, err = backfillLogs(ctx, chain, j.eventFilter, from, defaultBackfillRange,
+				func(log coretypes.Log) bool {
+					// Backfilled logs are never removed, so they are only buffered.
+					j.confirmations.addLog(log)
+					return true
+				},
+			); err != nil {
+				return err
+			}
+		}
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err = <-logSub.Err():
+				return err
+			case err = <-headSub.Err():
+				return err
+			case log := <-logs:
+				if !deliver(j.confirmations.addLog(log), quit) {
+					return nil
+				}
+			case head := <-heads:
+				events, headErr := j.confirmations.addHead(ctx, chain, head)
+				if headErr != nil {
+					return headErr
+				}
+				if !deliver(events, quit) {
+					return nil
+				}
+			}
+		}
+	})
+	return j.sub, ch, nil
+}
+
+// Unsubscribe unsubscribes from the filter query and new heads.
+func (j *ConfirmedEthFilterSub) Unsubscribe(_ context.Context) {
+	if j.sub != nil {
+		j.sub.Unsubscribe()
+	}
+}
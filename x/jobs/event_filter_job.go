@@ -10,37 +10,45 @@ import (
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// Compile time check to ensure that EthFilterSub implements job.EthSubscribable, and optionally
-// the basic job's Setup and Teardown methods.
+// Compile time check to ensure that EthFilterSub implements job.EthSubscribable. It is
+// checkpointed itself. The optional methods of the basic job are forwarded, see job.Forwarder.
 var (
 	_ job.EthSubscribable = (*EthFilterSub)(nil)
-	_ job.HasSetup        = (*EthFilterSub)(nil)
-	_ job.HasTeardown     = (*EthFilterSub)(nil)
+	_ job.Checkpointed    = (*EthFilterSub)(nil)
 )
 
 // EthFilterSub allows you to subscribe a basic job to an ethereum event.
 type EthFilterSub struct {
-	job.Basic
+	job.Forwarder
 	eventFilter ethereum.FilterQuery
 	sub         ethereum.Subscription
+	checkpoints logCheckpointer
 }
 
 // NewEthFilterSub creates a new EthFilterSub
 // eventFilter is a ethereum.FilterQuery.
-func NewEthFilterSub(job job.Basic, eventFilter ethereum.FilterQuery) *EthFilterSub {
+func NewEthFilterSub(basic job.Basic, eventFilter ethereum.FilterQuery) *EthFilterSub {
 	return &EthFilterSub{
-		Basic:       job,
+		Forwarder:   job.Forward(basic),
 		eventFilter: eventFilter,
 	}
 }
 
-// Subscribe subscribes to all events based on ethereum filter query.
+// WithBackfill makes the subscription first backfill the logs since the configured start block, or
+// since the job's checkpoint if it has one, before handing off to the live logs.
+func (j *EthFilterSub) WithBackfill(cfg BackfillConfig) *EthFilterSub {
+	j.checkpoints.setBackfill(cfg)
+	return j
+}
+
+// Subscribe subscribes to all events based on ethereum filter query. If checkpointed or
+// backfilled, it resumes from the last log that was delivered, or else from the restored
+// checkpoint or the backfill start block.
 func (j *EthFilterSub) Subscribe(
 	ctx context.Context,
 ) (ethereum.Subscription, chan coretypes.Log, error) {
 	sCtx := sdk.UnwrapContext(ctx)
-	ch := make(chan coretypes.Log)
-	sub, err := sCtx.Chain().SubscribeFilterLogs(ctx, j.eventFilter, ch)
+	sub, ch, err := j.checkpoints.subscribe(ctx, sCtx.Chain(), j.eventFilter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,16 +63,13 @@ func (j *EthFilterSub) Unsubscribe(_ context.Context) {
 	}
 }
 
-func (j *EthFilterSub) Setup(ctx context.Context) error {
-	if setupJob, ok := j.Basic.(job.HasSetup); ok {
-		return setupJob.Setup(ctx)
-	}
-	return nil
+// CheckpointOf implements job.Checkpointed, the checkpoint of a log is its position.
+func (j *EthFilterSub) CheckpointOf(input any) (job.Checkpoint, bool) {
+	return j.checkpoints.checkpointOf(input)
 }
 
-func (j *EthFilterSub) Teardown() error {
-	if setupJob, ok := j.Basic.(job.HasTeardown); ok {
-		return setupJob.Teardown()
-	}
+// RestoreCheckpoint implements job.Checkpointed.
+func (j *EthFilterSub) RestoreCheckpoint(_ context.Context, cp *job.Checkpoint) error {
+	j.checkpoints.restore(cp)
 	return nil
 }
@@ -12,41 +12,48 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// Compile time check to ensure that EthEventSub implements job.EthSubscribable, and optionally the
-// basic job's Setup and Teardown methods.
+// Compile time check to ensure that EthEventSub implements job.EthSubscribable. It is checkpointed
+// itself. The optional methods of the basic job are forwarded, see job.Forwarder.
 var (
 	_ job.EthSubscribable = (*EthEventSub)(nil)
-	_ job.HasSetup        = (*EthEventSub)(nil)
-	_ job.HasTeardown     = (*EthEventSub)(nil)
+	_ job.Checkpointed    = (*EthEventSub)(nil)
 )
 
 // EthEventSub allows you to subscribe a basic job to an ethereum event.
 type EthEventSub struct {
-	job.Basic
+	job.Forwarder
 	contractAddress common.Address
 	event           string
 	sub             ethereum.Subscription
+	checkpoints     logCheckpointer
 }
 
 // NewEthSub creates a new EthEventSub.
-func NewEthSub(job job.Basic, contractAddr string, event string) *EthEventSub {
+func NewEthSub(basic job.Basic, contractAddr string, event string) *EthEventSub {
 	return &EthEventSub{
-		Basic:           job,
+		Forwarder:       job.Forward(basic),
 		contractAddress: common.HexToAddress(contractAddr),
 		event:           event,
 	}
 }
 
-// Subscribe subscribes to an ethereum event.
+// WithBackfill makes the subscription first backfill the logs since the configured start block, or
+// since the job's checkpoint if it has one, before handing off to the live logs.
+func (j *EthEventSub) WithBackfill(cfg BackfillConfig) *EthEventSub {
+	j.checkpoints.setBackfill(cfg)
+	return j
+}
+
+// Subscribe subscribes to an ethereum event. If checkpointed or backfilled, it resumes from the
+// last log that was delivered, or else from the restored checkpoint or the backfill start block.
 func (j *EthEventSub) Subscribe(
 	ctx context.Context,
 ) (ethereum.Subscription, chan coretypes.Log, error) {
 	sCtx := sdk.UnwrapContext(ctx)
-	ch := make(chan coretypes.Log)
-	sub, err := sCtx.Chain().SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+	sub, ch, err := j.checkpoints.subscribe(ctx, sCtx.Chain(), ethereum.FilterQuery{
 		Addresses: []common.Address{j.contractAddress},
 		Topics:    [][]common.Hash{{crypto.Keccak256Hash([]byte(j.event))}},
-	}, ch)
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -62,16 +69,13 @@ func (j *EthEventSub) Unsubscribe(_ context.Context) {
 	}
 }
 
-func (j *EthEventSub) Setup(ctx context.Context) error {
-	if setupJob, ok := j.Basic.(job.HasSetup); ok {
-		return setupJob.Setup(ctx)
-	}
-	return nil
+// CheckpointOf implements job.Checkpointed, the checkpoint of a log is its position.
+func (j *EthEventSub) CheckpointOf(input any) (job.Checkpoint, bool) {
+	return j.checkpoints.checkpointOf(input)
 }
 
-func (j *EthEventSub) Teardown() error {
-	if setupJob, ok := j.Basic.(job.HasTeardown); ok {
-		return setupJob.Teardown()
-	}
+// RestoreCheckpoint implements job.Checkpointed.
+func (j *EthEventSub) RestoreCheckpoint(_ context.Context, cp *job.Checkpoint) error {
+	j.checkpoints.restore(cp)
 	return nil
 }
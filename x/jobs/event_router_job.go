@@ -0,0 +1,173 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/berachain/offchain-sdk/job"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Compile time check to ensure that EthEventRouter implements job.EthSubscribable. It is
+// checkpointed itself.
+var (
+	_ job.EthSubscribable = (*EthEventRouter)(nil)
+	_ job.Checkpointed    = (*EthEventRouter)(nil)
+)
+
+// EventHandler handles a log of the event of a contract it is registered for.
+type EventHandler func(ctx context.Context, log coretypes.Log) (any, error)
+
+// eventKey identifies the event of a contract, by the contract's address and the event's topic.
+type eventKey struct {
+	address common.Address
+	topic   common.Hash
+}
+
+// EthEventRouter is a job that subscribes to the events of many contracts with a single
+// subscription, executing each log with the handler registered for its contract and event.
+type EthEventRouter struct {
+	registryKey string
+	sub         ethereum.Subscription
+	checkpoints logCheckpointer
+
+	mu       sync.RWMutex
+	handlers map[eventKey]EventHandler
+	// subscribed is whether the router subscribed, after which no handlers may be registered.
+	subscribed bool
+}
+
+// NewEthEventRouter creates a new EthEventRouter job with the given registry key. Handlers must be
+// registered before the job is run.
+func NewEthEventRouter(registryKey string) *EthEventRouter {
+	return &EthEventRouter{
+		registryKey: registryKey,
+		handlers:    make(map[eventKey]EventHandler),
+	}
+}
+
+// Handle registers the handler for the given event of the given contract, where the event is its
+// signature, e.g. "Transfer(address,address,uint256)". It fails once the router subscribed, since
+// the subscription would not include the event.
+func (r *EthEventRouter) Handle(contractAddr string, event string, handler EventHandler) error {
+	key := eventKey{
+		address: common.HexToAddress(contractAddr),
+		topic:   crypto.Keccak256Hash([]byte(event)),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.subscribed {
+		return fmt.Errorf(
+			"cannot register handler for event %s of contract %s after subscribing",
+			event, contractAddr,
+		)
+	}
+	if _, ok := r.handlers[key]; ok {
+		return fmt.Errorf(
+			"handler for event %s of contract %s is already registered", event, contractAddr,
+		)
+	}
+	r.handlers[key] = handler
+	return nil
+}
+
+// WithBackfill makes the subscription first backfill the logs since the configured start block, or
+// since the job's checkpoint if it has one, before handing off to the live logs.
+func (r *EthEventRouter) WithBackfill(cfg BackfillConfig) *EthEventRouter {
+	r.checkpoints.setBackfill(cfg)
+	return r
+}
+
+// RegistryKey implements job.Basic.
+func (r *EthEventRouter) RegistryKey() string {
+	return r.registryKey
+}
+
+// Execute implements job.Basic, it executes the log with the handler registered for its contract
+// and event. Logs that match no handler, which the combined filter query may let through, are
+// ignored.
+func (r *EthEventRouter) Execute(ctx context.Context, args any) (any, error) {
+	log, ok := args.(coretypes.Log)
+	if !ok || len(log.Topics) == 0 {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	handler, ok := r.handlers[eventKey{address: log.Address, topic: log.Topics[0]}]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return handler(ctx, log)
+}
+
+// Subscribe subscribes once to the events of all contracts that handlers are registered for. It
+// fails if none are, since the query would match the logs of all contracts. If checkpointed or
+// backfilled, it resumes from the last log that was delivered, or else from the restored
+// checkpoint or the backfill start block.
+func (r *EthEventRouter) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan coretypes.Log, error) {
+	query, err := r.filterQuery()
+	if err != nil {
+		return nil, nil, err
+	}
+	sCtx := sdk.UnwrapContext(ctx)
+	sub, ch, err := r.checkpoints.subscribe(ctx, sCtx.Chain(), query)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.sub = sub
+	return sub, ch, nil
+}
+
+// filterQuery returns the query combining the events of all contracts that handlers are registered
+// for, marking the router as subscribed.
+func (r *EthEventRouter) filterQuery() (ethereum.FilterQuery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.handlers) == 0 {
+		return ethereum.FilterQuery{}, fmt.Errorf("no event handlers registered for %s", r.registryKey)
+	}
+	r.subscribed = true
+
+	var (
+		addresses []common.Address
+		topics    []common.Hash
+	)
+	for key := range r.handlers {
+		if !slices.Contains(addresses, key.address) {
+			addresses = append(addresses, key.address)
+		}
+		if !slices.Contains(topics, key.topic) {
+			topics = append(topics, key.topic)
+		}
+	}
+	return ethereum.FilterQuery{Addresses: addresses, Topics: [][]common.Hash{topics}}, nil
+}
+
+// Unsubscribe unsubscribes from the events.
+func (r *EthEventRouter) Unsubscribe(_ context.Context) {
+	if r.sub != nil {
+		r.sub.Unsubscribe()
+	}
+}
+
+// CheckpointOf implements job.Checkpointed, the checkpoint of a log is its position.
+func (r *EthEventRouter) CheckpointOf(input any) (job.Checkpoint, bool) {
+	return r.checkpoints.checkpointOf(input)
+}
+
+// RestoreCheckpoint implements job.Checkpointed.
+func (r *EthEventRouter) RestoreCheckpoint(_ context.Context, cp *job.Checkpoint) error {
+	r.checkpoints.restore(cp)
+	return nil
+}
@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthEventRouterHandlers(t *testing.T) {
+	r := NewEthEventRouter("router")
+	_, err := r.filterQuery()
+	require.ErrorContains(t, err, "no event handlers registered")
+
+	const contract = "0x0000000000000000000000000000000000000001"
+	require.NoError(t, r.Handle(contract, "Transfer(address,address,uint256)", nil))
+	require.Error(t, r.Handle(contract, "Transfer(address,address,uint256)", nil))
+
+	// Once subscribed, no more handlers may be registered, but resubscribing is fine.
+	query, err := r.filterQuery()
+	require.NoError(t, err)
+	require.Len(t, query.Addresses, 1)
+	require.ErrorContains(t, r.Handle(contract, "Approval(address,address,uint256)", nil),
+		"after subscribing")
+	_, err = r.filterQuery()
+	require.NoError(t, err)
+}
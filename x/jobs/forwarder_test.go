@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"github.com/berachain/offchain-sdk/job"
+)
+
+// forwarded is the set of optional methods of a basic job that its wrappers forward, see
+// job.Forwarder.
+type forwarded interface {
+	job.HasSetup
+	job.HasTeardownContext
+	job.Optional
+	job.Singleton
+	job.HasDependencies
+	job.HasExecutionPolicy
+	job.HasOnResult
+	job.HasOnError
+	job.HasSubscriptionPolicy
+	job.HasOnGiveUp
+}
+
+// Compile time check to ensure that the wrappers of basic jobs forward their optional methods.
+var (
+	_ forwarded = (*BlockHeaderWatcher)(nil)
+	_ forwarded = (*ConfirmedEthFilterSub)(nil)
+	_ forwarded = (*EthFilterSub)(nil)
+	_ forwarded = (*EthEventSub)(nil)
+	_ forwarded = (*EthPendingTxSub)(nil)
+	_ forwarded = (*TypedEventSub[struct{}])(nil)
+)
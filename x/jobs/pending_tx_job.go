@@ -0,0 +1,264 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Compile time check to ensure that EthPendingTxSub implements job.PendingTxSub. The optional
+// methods of the basic job are forwarded, see job.Forwarder.
+var (
+	_ job.PendingTxSub = (*EthPendingTxSub)(nil)
+)
+
+// pendingTxFetchers is the number of pending transactions that are fetched by hash at once.
+const pendingTxFetchers = 8
+
+// PendingTxFilter filters pending transactions by sender, recipient and method selector. An empty
+// field matches any transaction.
+type PendingTxFilter struct {
+	From      []common.Address
+	To        []common.Address
+	Selectors [][4]byte
+}
+
+// matches returns whether the given transaction passes the filter.
+func (f PendingTxFilter) matches(tx *coretypes.Transaction) bool {
+	if len(f.To) > 0 && (tx.To() == nil || !slices.Contains(f.To, *tx.To())) {
+		return false
+	}
+	if len(f.Selectors) > 0 {
+		if len(tx.Data()) < 4 || !slices.Contains(f.Selectors, [4]byte(tx.Data()[:4])) {
+			return false
+		}
+	}
+	if len(f.From) > 0 {
+		from, err := coretypes.Sender(coretypes.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil || !slices.Contains(f.From, from) {
+			return false
+		}
+	}
+	return true
+}
+
+// EthPendingTxSub allows you to subscribe a basic job to the pending transactions of the mempool
+// that pass a filter. The job is executed with the full *coretypes.Transaction.
+type EthPendingTxSub struct {
+	job.Forwarder
+	filter PendingTxFilter
+	sub    ethereum.Subscription
+}
+
+// NewEthPendingTxSub creates a new EthPendingTxSub with the given filter.
+func NewEthPendingTxSub(basic job.Basic, filter PendingTxFilter) *EthPendingTxSub {
+	return &EthPendingTxSub{
+		Forwarder: job.Forward(basic),
+		filter:    filter,
+	}
+}
+
+// Subscribe subscribes to new pending transactions, fetching them by hash with a bounded number of
+// fetchers at once. Transactions that fail to be fetched are logged and skipped. If no websocket
+// connection is available, the transaction pool is polled instead.
+func (j *EthPendingTxSub) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan *coretypes.Transaction, error) {
+	sCtx := sdk.UnwrapContext(ctx)
+	chain := sCtx.Chain()
+	hashes, hashSub, err := chain.SubscribePendingTransactions(ctx)
+	if errors.Is(err, eth.ErrClientNotFound) {
+		return j.poll(ctx, chain)
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *coretypes.Transaction)
+	j.sub = event.NewSubscription(func(quit <-chan struct{}) error {
+		defer hashSub.Unsubscribe()
+
+		// The fetchers stop once the subscription ends, whether it quit or failed.
+		var (
+			wg    sync.WaitGroup
+			work  = make(chan common.Hash)
+			ended = make(chan struct{})
+		)
+		defer wg.Wait()
+		defer close(work)
+		defer close(ended)
+		for range pendingTxFetchers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for hash := range work {
+					tx := j.fetch(ctx, chain, sCtx.Logger(), hash)
+					if tx == nil {
+						continue
+					}
+					select {
+					case <-quit:
+					case <-ended:
+					case ch <- tx:
+					}
+				}
+			}()
+		}
+
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err = <-hashSub.Err():
+				return err
+			case hash := <-hashes:
+				select {
+				case <-quit:
+					return nil
+				case work <- hash:
+				}
+			}
+		}
+	})
+	return j.sub, ch, nil
+}
+
+// fetch returns the pending transaction with the given hash if it passes the filter, or nil if it
+// does not, is no longer pending, or fails to be fetched.
+func (j *EthPendingTxSub) fetch(
+	ctx context.Context, chain eth.Reader, logger log.Logger, hash common.Hash,
+) *coretypes.Transaction {
+	tx, isPending, err := chain.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) || (err == nil && !isPending) {
+		// The transaction was already dropped or included.
+		return nil
+	} else if err != nil {
+		logger.Warn(
+			"failed to fetch pending transaction, skipping", "job", j.RegistryKey(), "tx", hash,
+			"err", err,
+		)
+		return nil
+	}
+	if !j.filter.matches(tx) {
+		return nil
+	}
+	return tx
+}
+
+// poll polls the transaction pool over HTTP for new pending transactions. The pool is inspected at
+// every poll, and the full transactions of the senders with new pending transactions are fetched.
+// Like the websocket subscription, only the transactions that are new since subscribing are
+// delivered.
+func (j *EthPendingTxSub) poll(
+	ctx context.Context, chain eth.Client,
+) (ethereum.Subscription, chan *coretypes.Transaction, error) {
+	// seen are the summaries of the pending transactions in the pool, by sender and nonce.
+	seen, err := inspectPending(ctx, chain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *coretypes.Transaction)
+	j.sub = event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(eth.DefaultPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			pending, pollErr := inspectPending(ctx, chain)
+			if pollErr != nil {
+				return pollErr
+			}
+			for sender, txs := range pending {
+				newNonces := j.newNonces(sender, txs, seen[sender])
+				if len(newNonces) == 0 {
+					continue
+				}
+				content, contentErr := chain.TxPoolContentFrom(ctx, sender)
+				if contentErr != nil {
+					return contentErr
+				}
+				for _, nonce := range newNonces {
+					tx, ok := content["pending"][nonce]
+					if !ok || !j.filter.matches(tx) {
+						continue
+					}
+					select {
+					case <-quit:
+						return nil
+					case ch <- tx:
+					}
+				}
+			}
+			seen = pending
+		}
+	})
+	return j.sub, ch, nil
+}
+
+// newNonces returns the nonces of the given sender's pending transactions that are new since the
+// last poll, or replaced, in order. The transactions to recipients that the filter does not match
+// are skipped without being fetched.
+func (j *EthPendingTxSub) newNonces(
+	sender common.Address, txs, seen map[uint64]string,
+) []uint64 {
+	if len(j.filter.From) > 0 && !slices.Contains(j.filter.From, sender) {
+		return nil
+	}
+
+	var nonces []uint64
+	for nonce, summary := range txs {
+		if seen[nonce] == summary {
+			continue
+		}
+		// Summaries are formatted as "<to>: <value> wei + <gas> gas × <price> wei".
+		to, _, _ := strings.Cut(summary, ":")
+		if len(j.filter.To) > 0 &&
+			(!common.IsHexAddress(to) || !slices.Contains(j.filter.To, common.HexToAddress(to))) {
+			continue
+		}
+		nonces = append(nonces, nonce)
+	}
+	slices.Sort(nonces)
+	return nonces
+}
+
+// inspectPending returns the summaries of the pending transactions in the pool, by sender and
+// nonce.
+func inspectPending(
+	ctx context.Context, chain eth.Reader,
+) (map[common.Address]map[uint64]string, error) {
+	pool, err := chain.TxPoolInspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if pool["pending"] == nil {
+		return make(map[common.Address]map[uint64]string), nil
+	}
+	return pool["pending"], nil
+}
+
+// Unsubscribe unsubscribes from pending transactions.
+func (j *EthPendingTxSub) Unsubscribe(_ context.Context) {
+	if j.sub != nil {
+		j.sub.Unsubscribe()
+	}
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testJob is a basic job that does nothing.
+type testJob struct{}
+
+func (testJob) RegistryKey() string                       { return "test" }
+func (testJob) Execute(context.Context, any) (any, error) { return nil, nil }
+
+// TestEthPendingTxSubSkipsErrors tests that the pending transactions that fail to be fetched are
+// skipped, without ending the subscription.
+func TestEthPendingTxSubSkipsErrors(t *testing.T) {
+	var (
+		failing = common.HexToHash("0x1")
+		pending = common.HexToHash("0x2")
+		tx      = coretypes.NewTx(&coretypes.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1)})
+		hashes  = make(chan common.Hash)
+	)
+	client := new(mocks.Client)
+	client.On("SubscribePendingTransactions", mock.Anything).Return(
+		hashes, event.NewSubscription(func(quit <-chan struct{}) error {
+			<-quit
+			return nil
+		}), nil,
+	)
+	client.On("TransactionByHash", mock.Anything, failing).Return(
+		nil, false, errors.New("request timed out"),
+	)
+	client.On("TransactionByHash", mock.Anything, pending).Return(tx, true, nil)
+
+	j := NewEthPendingTxSub(testJob{}, PendingTxFilter{})
+	ctx := sdk.NewContext(
+		context.Background(), client, log.NewLogger(os.Stdout, "test"), nil, nil,
+	)
+	sub, ch, err := j.Subscribe(ctx)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	hashes <- failing
+	hashes <- pending
+	select {
+	case received := <-ch:
+		require.Equal(t, tx.Hash(), received.Hash())
+	case err = <-sub.Err():
+		t.Fatalf("unexpected subscription error: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the pending transaction")
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// subscribeNewHead subscribes to new block headers, or polls for them if no websocket connection
+// is available.
+func subscribeNewHead(
+	ctx context.Context, chain eth.Client,
+) (chan *coretypes.Header, ethereum.Subscription, error) {
+	heads, sub, err := chain.SubscribeNewHead(ctx)
+	if errors.Is(err, eth.ErrClientNotFound) {
+		heads, sub = eth.PollNewHeads(ctx, chain, eth.DefaultPollInterval)
+		return heads, sub, nil
+	}
+	return heads, sub, err
+}
+
+// subscribeFilterLogs subscribes to the logs of the given query, or polls for them if no websocket
+// connection is available.
+func subscribeFilterLogs(
+	ctx context.Context, chain eth.Client, query ethereum.FilterQuery, ch chan<- coretypes.Log,
+) (ethereum.Subscription, error) {
+	sub, err := chain.SubscribeFilterLogs(ctx, query, ch)
+	if errors.Is(err, eth.ErrClientNotFound) {
+		return eth.PollFilterLogs(ctx, chain, query, ch, eth.DefaultPollInterval)
+	}
+	return sub, err
+}
@@ -0,0 +1,174 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+	"github.com/berachain/offchain-sdk/job"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Compile time check to ensure that TypedEventSub implements job.Subscription. It is checkpointed
+// itself. The optional methods of the basic job are forwarded, see job.Forwarder.
+var (
+	_ job.Subscription[any] = (*TypedEventSub[struct{}])(nil)
+	_ job.Checkpointed      = (*TypedEventSub[struct{}])(nil)
+)
+
+// TypedEventSub allows you to subscribe a basic job to an ethereum event, decoded from its ABI.
+// The job is executed with a *E for every log of the event, where E is a struct with a field for
+// each of the event's arguments, like the event structs generated by abigen. If E has a Raw
+// coretypes.Log field, it is set to the decoded log. Removed logs, i.e. the logs of blocks that
+// dropped out of the canonical chain, are not delivered, since they would look like new events;
+// use ConfirmedEthFilterSub to be notified of reverted logs instead.
+type TypedEventSub[E any] struct {
+	job.Forwarder
+	contract    *bind.BoundContract
+	eventName   string
+	eventFilter ethereum.FilterQuery
+	sub         ethereum.Subscription
+	checkpoints logCheckpointer
+}
+
+// NewTypedEventSub creates a new TypedEventSub for the event with the given name in the ABI of the
+// given metadata, emitted by the given contract. The topics optionally filter the event's indexed
+// arguments in order, with nil matching any value, e.g. []any{from} to only match the transfers
+// from an address.
+func NewTypedEventSub[E any](
+	basic job.Basic, metaData *bind.MetaData, contractAddr string, eventName string,
+	topics ...[]any,
+) (*TypedEventSub[E], error) {
+	if kind := reflect.TypeOf((*E)(nil)).Elem().Kind(); kind != reflect.Struct {
+		return nil, fmt.Errorf("event type must be a struct, got %s", kind)
+	}
+	contractABI, err := metaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := contractABI.Events[eventName]; !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", eventName)
+	}
+	argTopics, err := abi.MakeTopics(topics...)
+	if err != nil {
+		return nil, fmt.Errorf("make topics of event %s: %w", eventName, err)
+	}
+
+	packer := &types.Packer{MetaData: metaData}
+	address := common.HexToAddress(contractAddr)
+	return &TypedEventSub[E]{
+		Forwarder: job.Forward(basic),
+		contract:  bind.NewBoundContract(address, *contractABI, nil, nil, nil),
+		eventName: eventName,
+		eventFilter: ethereum.FilterQuery{
+			Addresses: []common.Address{address},
+			Topics: append(
+				[][]common.Hash{{packer.MustGetEventSig(eventName)}}, argTopics...,
+			),
+		},
+	}, nil
+}
+
+// WithBackfill makes the subscription first backfill the events since the configured start block,
+// or since the job's checkpoint if it has one, before handing off to the live events.
+func (j *TypedEventSub[E]) WithBackfill(cfg BackfillConfig) *TypedEventSub[E] {
+	j.checkpoints.setBackfill(cfg)
+	return j
+}
+
+// Subscribe subscribes to the event, delivering every log decoded. Removed logs are skipped, and
+// the logs that fail to be decoded are logged and skipped. If checkpointed or backfilled, it
+// resumes from the last log that was delivered, or else from the restored checkpoint or the
+// backfill start block.
+func (j *TypedEventSub[E]) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan any, error) {
+	sCtx := sdk.UnwrapContext(ctx)
+	logSub, logs, err := j.checkpoints.subscribe(ctx, sCtx.Chain(), j.eventFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan any)
+	j.sub = event.NewSubscription(func(quit <-chan struct{}) error {
+		defer logSub.Unsubscribe()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err = <-logSub.Err():
+				return err
+			case log := <-logs:
+				if log.Removed {
+					sCtx.Logger().Debug(
+						"skipping removed event", "job", j.RegistryKey(), "event", j.eventName,
+						"tx", log.TxHash,
+					)
+					continue
+				}
+				ev, decodeErr := j.decode(log)
+				if decodeErr != nil {
+					sCtx.Logger().Error(
+						"failed to decode event", "job", j.RegistryKey(), "event", j.eventName,
+						"tx", log.TxHash, "err", decodeErr,
+					)
+					continue
+				}
+				select {
+				case <-quit:
+					return nil
+				case ch <- ev:
+				}
+			}
+		}
+	})
+	return j.sub, ch, nil
+}
+
+// decode decodes the given log into the event's struct.
+func (j *TypedEventSub[E]) decode(log coretypes.Log) (*E, error) {
+	ev := new(E)
+	if err := j.contract.UnpackLog(ev, j.eventName, log); err != nil {
+		return nil, err
+	}
+	if raw := reflect.ValueOf(ev).Elem().FieldByName("Raw"); raw.IsValid() && raw.CanSet() &&
+		raw.Type() == reflect.TypeOf(log) {
+		raw.Set(reflect.ValueOf(log))
+	}
+	return ev, nil
+}
+
+// Unsubscribe unsubscribes from the event.
+func (j *TypedEventSub[E]) Unsubscribe(_ context.Context) {
+	if j.sub != nil {
+		j.sub.Unsubscribe()
+	}
+}
+
+// CheckpointOf implements job.Checkpointed, the checkpoint of an event is the position of its log.
+// Events are only checkpointed if E has a Raw coretypes.Log field.
+func (j *TypedEventSub[E]) CheckpointOf(input any) (job.Checkpoint, bool) {
+	ev, ok := input.(*E)
+	if !ok || ev == nil {
+		return job.Checkpoint{}, false
+	}
+	raw := reflect.ValueOf(ev).Elem().FieldByName("Raw")
+	if !raw.IsValid() || !raw.CanInterface() {
+		return job.Checkpoint{}, false
+	}
+	return j.checkpoints.checkpointOf(raw.Interface())
+}
+
+// RestoreCheckpoint implements job.Checkpointed.
+func (j *TypedEventSub[E]) RestoreCheckpoint(_ context.Context, cp *job.Checkpoint) error {
+	j.checkpoints.restore(cp)
+	return nil
+}
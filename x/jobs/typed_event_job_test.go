@@ -0,0 +1,253 @@
+package jobs
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth/mocks"
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenMetaData is the ABI of a token contract with a single Transfer event.
+var tokenMetaData = &bind.MetaData{
+	ABI: `[{"type":"event","name":"Transfer","anonymous":false,"inputs":[` +
+		`{"name":"from","type":"address","indexed":true},` +
+		`{"name":"to","type":"address","indexed":true},` +
+		`{"name":"value","type":"uint256","indexed":false}]}]`,
+}
+
+var (
+	tokenAddr   = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	transferSig = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	alice       = common.HexToAddress("0x000000000000000000000000000000000000a11c")
+	bob         = common.HexToAddress("0x0000000000000000000000000000000000000b0b")
+)
+
+// transferEvent is the Transfer event, as generated by abigen.
+type transferEvent struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   coretypes.Log
+}
+
+// rawlessTransferEvent is the Transfer event without the log it was decoded from.
+type rawlessTransferEvent struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+}
+
+// transferLog returns the log of a transfer of the given value from alice to bob.
+func transferLog(value int64, blockNumber uint64, index uint) coretypes.Log {
+	return coretypes.Log{
+		Address: tokenAddr,
+		Topics: []common.Hash{
+			transferSig, common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes()),
+		},
+		Data:        common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+		BlockNumber: blockNumber,
+		Index:       index,
+	}
+}
+
+func TestNewTypedEventSub(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		event    string
+		topics   [][]any
+		expected [][]common.Hash
+		err      string
+	}{
+		{
+			name:     "event only",
+			event:    "Transfer",
+			expected: [][]common.Hash{{transferSig}},
+		},
+		{
+			name:     "first indexed argument",
+			event:    "Transfer",
+			topics:   [][]any{{alice}},
+			expected: [][]common.Hash{{transferSig}, {common.BytesToHash(alice.Bytes())}},
+		},
+		{
+			name:   "any first indexed argument",
+			event:  "Transfer",
+			topics: [][]any{nil, {alice, bob}},
+			expected: [][]common.Hash{
+				{transferSig}, nil,
+				{common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes())},
+			},
+		},
+		{
+			name:  "unknown event",
+			event: "Approval",
+			err:   "event Approval not found in ABI",
+		},
+		{
+			name:   "unsupported topic",
+			event:  "Transfer",
+			topics: [][]any{{1.5}},
+			err:    "make topics of event Transfer",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			j, err := NewTypedEventSub[transferEvent](
+				testJob{}, tokenMetaData, tokenAddr.Hex(), tc.event, tc.topics...,
+			)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []common.Address{tokenAddr}, j.eventFilter.Addresses)
+			require.Equal(t, tc.expected, j.eventFilter.Topics)
+		})
+	}
+
+	_, err := NewTypedEventSub[*transferEvent](testJob{}, tokenMetaData, tokenAddr.Hex(), "Transfer")
+	require.ErrorContains(t, err, "event type must be a struct")
+}
+
+func TestTypedEventSubDecode(t *testing.T) {
+	j, err := NewTypedEventSub[transferEvent](testJob{}, tokenMetaData, tokenAddr.Hex(), "Transfer")
+	require.NoError(t, err)
+	rawless, err := NewTypedEventSub[rawlessTransferEvent](
+		testJob{}, tokenMetaData, tokenAddr.Hex(), "Transfer",
+	)
+	require.NoError(t, err)
+
+	valid := transferLog(5, 10, 2)
+	truncated := transferLog(5, 10, 2)
+	truncated.Data = truncated.Data[:16]
+	noTopics := transferLog(5, 10, 2)
+	noTopics.Topics = noTopics.Topics[:1]
+	otherEvent := transferLog(5, 10, 2)
+	otherEvent.Topics[0] = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+	for _, tc := range []struct {
+		name string
+		log  coretypes.Log
+		err  bool
+	}{
+		{name: "valid", log: valid},
+		{name: "truncated data", log: truncated, err: true},
+		{name: "missing topics", log: noTopics, err: true},
+		{name: "other event", log: otherEvent, err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ev, err := j.decode(tc.log)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &transferEvent{From: alice, To: bob, Value: big.NewInt(5), Raw: tc.log}, ev)
+
+			// Without a Raw field, only the event's arguments are decoded.
+			rawlessEv, err := rawless.decode(tc.log)
+			require.NoError(t, err)
+			require.Equal(t, &rawlessTransferEvent{From: alice, To: bob, Value: big.NewInt(5)}, rawlessEv)
+		})
+	}
+}
+
+// TestTypedEventSubSkipsUndecodable tests that the removed logs and the logs that fail to be
+// decoded are skipped, without ending the subscription.
+func TestTypedEventSubSkipsUndecodable(t *testing.T) {
+	j, err := NewTypedEventSub[transferEvent](
+		testJob{}, tokenMetaData, tokenAddr.Hex(), "Transfer", []any{alice},
+	)
+	require.NoError(t, err)
+
+	var logs chan<- coretypes.Log
+	client := new(mocks.Client)
+	client.On("SubscribeFilterLogs", mock.Anything, j.eventFilter, mock.Anything).Run(
+		func(args mock.Arguments) { logs = args.Get(2).(chan<- coretypes.Log) },
+	).Return(event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	}), nil)
+
+	ctx := sdk.NewContext(
+		context.Background(), client, log.NewLogger(os.Stdout, "test"), nil, nil,
+	)
+	sub, ch, err := j.Subscribe(ctx)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	undecodable := transferLog(1, 10, 0)
+	undecodable.Data = undecodable.Data[:16]
+	removed := transferLog(2, 10, 1)
+	removed.Removed = true
+	valid := transferLog(3, 10, 2)
+	logs <- undecodable
+	logs <- removed
+	logs <- valid
+	select {
+	case received := <-ch:
+		require.Equal(t, &transferEvent{From: alice, To: bob, Value: big.NewInt(3), Raw: valid},
+			received)
+	case err = <-sub.Err():
+		t.Fatalf("unexpected subscription error: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the event")
+	}
+}
+
+func TestTypedEventSubCheckpointOf(t *testing.T) {
+	j, err := NewTypedEventSub[transferEvent](testJob{}, tokenMetaData, tokenAddr.Hex(), "Transfer")
+	require.NoError(t, err)
+	rawless, err := NewTypedEventSub[rawlessTransferEvent](
+		testJob{}, tokenMetaData, tokenAddr.Hex(), "Transfer",
+	)
+	require.NoError(t, err)
+
+	removed := transferLog(5, 10, 2)
+	removed.Removed = true
+	for _, tc := range []struct {
+		name     string
+		sub      job.Checkpointed
+		input    any
+		expected *job.Checkpoint
+	}{
+		{
+			name:     "event",
+			sub:      j,
+			input:    &transferEvent{Raw: transferLog(5, 10, 2)},
+			expected: &job.Checkpoint{BlockNumber: 10, LogIndex: 2},
+		},
+		{
+			name:     "removed event",
+			sub:      j,
+			input:    &transferEvent{Raw: removed},
+			expected: &job.Checkpoint{BlockNumber: 9, LogIndex: math.MaxUint},
+		},
+		{name: "nil event", sub: j, input: (*transferEvent)(nil)},
+		{name: "other type", sub: j, input: transferLog(5, 10, 2)},
+		{name: "event without raw log", sub: rawless, input: &rawlessTransferEvent{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cp, ok := tc.sub.CheckpointOf(tc.input)
+			if tc.expected == nil {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Equal(t, *tc.expected, cp)
+		})
+	}
+}